@@ -2,7 +2,11 @@ package args
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"strings"
+
+	"github.com/chremoas/chremoas/cmdline"
 	proto "github.com/chremoas/chremoas/proto"
 	"golang.org/x/net/context"
 )
@@ -11,11 +15,113 @@ type Args struct {
 	cmdName string
 	argMap  map[string]*Command
 	argList []string
+	parent  *Args
+	auth    Authorizer
+	// middleware wraps the commands, see Use
+	middleware []Middleware
 }
 
 type Command struct {
 	Funcptr func(ctx context.Context, request *proto.ExecRequest) string
 	Help    string
+	// Args and Flags are parsed and checked before the command runs
+	Args  []*Arg
+	Flags []*Flag
+	// Handler is called with the parsed arguments and flags instead of
+	// Funcptr when it is set
+	Handler func(ctx context.Context, request *proto.ExecRequest, values *Values) string
+	// Group holds the subcommands of a command group, see Args.Group
+	Group *Args
+	// Permissions are the permission groups allowed to run the command, a
+	// user needs to be in any of them. See SetAuthorizer.
+	Permissions []string
+	// Examples are shown in the help of the command, without the prefix
+	Examples []string
+	// Hidden commands still run but aren't listed in help
+	Hidden bool
+	// Continue is called with the user's answer when the command asked them
+	// something with Ask, session is what it passed to Ask
+	Continue func(ctx context.Context, request *proto.ExecRequest, session, answer string) string
+}
+
+// declared reports whether c declares what it takes, commands that don't
+// are passed whatever the user typed
+func (c *Command) declared() bool {
+	return c.Handler != nil || len(c.Args) > 0 || len(c.Flags) > 0
+}
+
+// schema describes the arguments and flags of c
+func (c *Command) schema() *proto.CommandSchema {
+	schema := &proto.CommandSchema{
+		Examples:   c.Examples,
+		Permission: strings.Join(c.Permissions, " or "),
+	}
+
+	for _, arg := range c.Args {
+		schema.Arguments = append(schema.Arguments, &proto.Argument{
+			Name:         arg.Name,
+			Description:  arg.Help,
+			Type:         argumentType(arg.Type),
+			Required:     arg.Required,
+			DefaultValue: arg.Default,
+			Choices:      arg.Choices,
+			Variadic:     arg.Variadic,
+		})
+	}
+
+	for _, f := range c.Flags {
+		schema.Flags = append(schema.Flags, &proto.Flag{
+			Name:         f.Name,
+			Short:        f.Short,
+			Description:  f.Help,
+			Type:         argumentType(f.Type),
+			Required:     f.Required,
+			DefaultValue: f.Default,
+			Choices:      f.Choices,
+		})
+	}
+
+	return schema
+}
+
+// check checks c declares its arguments and flags in a way they can be
+// parsed
+func (c *Command) check() error {
+	for i, a := range c.Args {
+		if a.Variadic && i != len(c.Args)-1 {
+			return fmt.Errorf("argument <%s> is variadic but not the last one", a.Name)
+		}
+		if a.Type == Enum && len(a.Choices) == 0 {
+			return fmt.Errorf("argument <%s> is an enum without choices", a.Name)
+		}
+		if len(a.Default) > 0 {
+			if err := cmdline.Check(argumentType(a.Type), a.Choices, a.Default); err != nil {
+				return fmt.Errorf("argument <%s> has an invalid default: %v", a.Name, err)
+			}
+		}
+	}
+
+	for _, f := range c.Flags {
+		if f.Type == Enum && len(f.Choices) == 0 {
+			return fmt.Errorf("flag --%s is an enum without choices", f.Name)
+		}
+		if len(f.Default) > 0 {
+			if err := cmdline.Check(argumentType(f.Type), f.Choices, f.Default); err != nil {
+				return fmt.Errorf("flag --%s has an invalid default: %v", f.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Command) flag(name string) *Flag {
+	for _, f := range c.Flags {
+		if f.Name == name || (len(f.Short) > 0 && f.Short == name) {
+			return f
+		}
+	}
+	return nil
 }
 
 func NewArg(cmdName string) *Args {
@@ -25,43 +131,232 @@ func NewArg(cmdName string) *Args {
 	return a
 }
 
+// Add adds the subcommand name. Like flag redefinitions in the flag package
+// it panics on mistakes in the command's declarations, such as a variadic
+// argument that isn't the last one or a default of the wrong type, so they
+// show up when the service starts rather than when someone runs it.
 func (a *Args) Add(name string, command *Command) {
+	if err := command.check(); err != nil {
+		panic(fmt.Sprintf("args: %s %s: %v", a.cmdName, name, err))
+	}
+
 	a.argList = append(a.argList, name)
 	a.argMap[name] = command
 }
 
+// Group adds a group of subcommands named name, like filter in
+// !role filter add <name>, and returns it so subcommands can be added to it.
+// Groups can have groups of their own.
+func (a *Args) Group(name, help string) *Args {
+	g := NewArg(a.cmdName + " " + name)
+	g.parent = a
+	a.Add(name, &Command{Help: help, Group: g})
+	return g
+}
+
+// Exec runs the subcommand named in req, or continues the one that asked the
+// user something. Everything done for it, like checking permissions and
+// parsing arguments, runs inside the middleware. Unknown subcommands, invalid
+// arguments and failures checking permissions are returned, other errors are
+// sent back in the response.
 func (a Args) Exec(ctx context.Context, req *proto.ExecRequest, rsp *proto.ExecResponse) error {
-	var response string
+	ctx, asked := asking(deciding(ctx))
 
-	if len(req.Args) == 1 || req.Args[1] == "help" {
-		response = a.help()
+	var (
+		response string
+		err      error
+	)
+
+	if len(req.SessionId) > 0 {
+		response, err = a.resume(ctx, req)
 	} else {
-		f, ok := a.argMap[req.Args[1]]
-		if ok {
-			response = f.Funcptr(ctx, req)
-		} else {
-			return fmt.Errorf("not a valid subcommand: %s", req.Args[1])
-		}
+		ctx = context.WithValue(ctx, commandKey{}, a.pathOf(req.Args))
+		response, err = a.exec(ctx, req, 1)
+	}
+
+	var failed *execError
+	if errors.As(err, &failed) {
+		return err
+	}
+	if err != nil {
+		rsp.Error = err.Error()
+		return nil
 	}
 
+	asked.set(rsp)
 	rsp.Result = []byte(response)
 	return nil
 }
 
-func (a Args) help() string {
+// execError is an error Exec returns instead of sending it back in the
+// response
+type execError struct {
+	err error
+}
+
+func (e *execError) Error() string {
+	return e.err.Error()
+}
+
+// failed returns an execError for the formatted message
+func failed(format string, a ...interface{}) error {
+	return &execError{fmt.Errorf(format, a...)}
+}
+
+// pathOf returns what names the command in args, like role filter add
+func (a *Args) pathOf(args []string) string {
+	path := a.cmdName
+	if len(args) == 0 {
+		return path
+	}
+
+	g := a
+	for _, name := range args[1:] {
+		path += " " + name
+
+		cmd, ok := g.argMap[name]
+		if !ok || cmd.Group == nil {
+			break
+		}
+		g = cmd.Group
+	}
+
+	return path
+}
+
+// exec runs the subcommand named by req.Args[depth] inside the middleware of
+// a
+func (a *Args) exec(ctx context.Context, req *proto.ExecRequest, depth int) (string, error) {
+	return a.around(func(ctx context.Context, req *proto.ExecRequest) (string, error) {
+		if len(req.Args) <= depth {
+			return a.help(ctx, req), nil
+		}
+
+		if req.Args[depth] == "help" {
+			return a.helpFor(ctx, req, req.Args[depth+1:])
+		}
+
+		f, ok := a.argMap[req.Args[depth]]
+		if !ok {
+			return "", failed("not a valid subcommand: %s", req.Args[depth])
+		}
+
+		allowed, err := a.allowed(ctx, req, f)
+		if err != nil {
+			return "", failed("error checking permissions: %v", err)
+		}
+		if !allowed {
+			return DeniedMessage, nil
+		}
+
+		if f.Group != nil {
+			return f.Group.exec(ctx, req, depth+1)
+		}
+
+		values, err := parse(f, req.Args[depth+1:])
+		if err != nil {
+			path := fmt.Sprintf("%s %s", a.cmdName, req.Args[depth])
+			return "", failed("%v\nusage: %s", err, cmdline.Usage(prefixOf(req)+path, f.schema()))
+		}
+
+		asks(ctx, req.Args[1:depth+1])
+
+		if f.Handler != nil {
+			return f.Handler(ctx, req, values), nil
+		}
+		return f.Funcptr(ctx, req), nil
+	})(ctx, req)
+}
+
+// help lists the subcommands the sender of req can run
+func (a Args) help(ctx context.Context, req *proto.ExecRequest) string {
 	var buffer bytes.Buffer
 
-	buffer.WriteString(fmt.Sprintf("Usage: !%s <subcommand> <arguments>\n", a.cmdName))
+	buffer.WriteString(fmt.Sprintf("Usage: %s%s <subcommand> <arguments>\n", prefixOf(req), a.cmdName))
 	buffer.WriteString("\nSubcommands:\n")
 
-	for cmd := range a.argList {
-		if a.argMap[a.argList[cmd]].Help != "" {
-			buffer.WriteString(fmt.Sprintf("\t%s: %s\n",
-				a.argList[cmd],
-				a.argMap[a.argList[cmd]].Help,
-			))
+	for _, name := range a.argList {
+		cmd := a.argMap[name]
+		if cmd.Hidden {
+			continue
+		}
+
+		if allowed, err := a.allowed(ctx, req, cmd); err != nil || !allowed {
+			continue
+		}
+
+		if cmd.Help == "" {
+			buffer.WriteString(fmt.Sprintf("\t%s\n", name))
+			continue
 		}
+		buffer.WriteString(fmt.Sprintf("\t%s: %s\n", name, cmd.Help))
 	}
 
 	return fmt.Sprintf("```%s```", buffer.String())
 }
+
+// helpFor returns the help of the subcommand named by names, like add for
+// !role help add or filter add for !role help filter add
+func (a Args) helpFor(ctx context.Context, req *proto.ExecRequest, names []string) (string, error) {
+	if len(names) == 0 {
+		return a.help(ctx, req), nil
+	}
+
+	cmd, ok := a.argMap[names[0]]
+	if !ok {
+		return "", failed("not a valid subcommand: %s", names[0])
+	}
+
+	// commands the user can't run are left out like in the list
+	if allowed, err := a.allowed(ctx, req, cmd); err != nil || !allowed {
+		return "", failed("not a valid subcommand: %s", names[0])
+	}
+
+	if cmd.Group != nil {
+		return cmd.Group.helpFor(ctx, req, names[1:])
+	}
+
+	return fmt.Sprintf("```%s```", describe(prefixOf(req), fmt.Sprintf("%s %s", a.cmdName, names[0]), cmd)), nil
+}
+
+// helpCommand describes the help subcommand every Args has
+var helpCommand = &Command{
+	Help: "Lists the subcommands or shows the help of one",
+	Args: []*Arg{{Name: "subcommand", Help: "the subcommand to show the help of", Variadic: true}},
+}
+
+// Schema describes the subcommands so the bot can check arguments and offer
+// them as slash commands, it goes in the HelpResponse of the service
+func (a Args) Schema() *proto.CommandSchema {
+	schema := &proto.CommandSchema{}
+
+	for _, name := range a.argList {
+		schema.Subcommands = append(schema.Subcommands, a.spec(name, a.argMap[name]))
+	}
+
+	if _, ok := a.argMap["help"]; !ok {
+		schema.Subcommands = append(schema.Subcommands, a.spec("help", helpCommand))
+	}
+
+	return schema
+}
+
+// spec describes the subcommand cmd named name
+func (a Args) spec(name string, cmd *Command) *proto.CommandSpec {
+	spec := &proto.CommandSpec{Name: name, Description: cmd.Help, Hidden: cmd.Hidden}
+
+	if cmd.Group != nil {
+		spec.Schema = cmd.Group.Schema()
+		spec.Schema.Permission = strings.Join(cmd.Permissions, " or ")
+		return spec
+	}
+
+	if !cmd.declared() {
+		return spec
+	}
+
+	spec.Schema = cmd.schema()
+	spec.Usage = cmdline.Usage(fmt.Sprintf("%s %s", a.cmdName, name), spec.Schema)
+
+	return spec
+}
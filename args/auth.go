@@ -0,0 +1,205 @@
+package args
+
+import (
+	"strings"
+	"sync"
+
+	protobuf "github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/client"
+	"golang.org/x/net/context"
+
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+// DeniedMessage answers users who run a command they don't have the
+// permissions for
+var DeniedMessage = "You don't have permission to do that."
+
+// Authorizer decides who can run commands that need permissions
+type Authorizer interface {
+	// Authorize reports whether user is in any of the permission groups
+	Authorize(ctx context.Context, user string, permissions []string) (bool, error)
+}
+
+// SetAuthorizer sets what checks the Permissions of commands, groups use the
+// one of the Args they are in unless they have their own. Commands that need
+// permissions are denied to everyone if there is none.
+func (a *Args) SetAuthorizer(auth Authorizer) {
+	a.auth = auth
+}
+
+// authorizer returns the Args with the authorizer a uses, or nil if there is
+// none
+func (a *Args) authorizer() *Args {
+	for ; a != nil; a = a.parent {
+		if a.auth != nil {
+			return a
+		}
+	}
+	return nil
+}
+
+// allowed reports whether the sender of req can run cmd
+func (a *Args) allowed(ctx context.Context, req *proto.ExecRequest, cmd *Command) (bool, error) {
+	if len(cmd.Permissions) == 0 {
+		return true, nil
+	}
+
+	owner := a.authorizer()
+	if owner == nil {
+		return false, nil
+	}
+
+	d, cached := ctx.Value(decisionsKey{}).(*decisions)
+	key := decision{owner: owner, permissions: strings.Join(cmd.Permissions, "\n")}
+
+	if cached {
+		d.Lock()
+		ok, decided := d.made[key]
+		d.Unlock()
+
+		if decided {
+			return ok, nil
+		}
+	}
+
+	ok, err := owner.auth.Authorize(ctx, userOf(req), cmd.Permissions)
+	if err == nil && cached {
+		d.Lock()
+		d.made[key] = ok
+		d.Unlock()
+	}
+
+	return ok, err
+}
+
+// userOf returns the id of the user that sent req, older bots only set the
+// sender
+func userOf(req *proto.ExecRequest) string {
+	if len(req.UserId) > 0 {
+		return req.UserId
+	}
+	parts := strings.SplitN(req.Sender, ":", 2)
+	return parts[len(parts)-1]
+}
+
+// MemoryAuthorizer keeps permission groups in memory, it is meant for tests
+type MemoryAuthorizer struct {
+	sync.Mutex
+	groups map[string]map[string]bool
+}
+
+func NewMemoryAuthorizer() *MemoryAuthorizer {
+	return &MemoryAuthorizer{groups: make(map[string]map[string]bool)}
+}
+
+// Grant adds user to permission groups
+func (m *MemoryAuthorizer) Grant(user string, permissions ...string) {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, p := range permissions {
+		if m.groups[p] == nil {
+			m.groups[p] = make(map[string]bool)
+		}
+		m.groups[p][user] = true
+	}
+}
+
+// Revoke removes user from permission groups
+func (m *MemoryAuthorizer) Revoke(user string, permissions ...string) {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, p := range permissions {
+		delete(m.groups[p], user)
+	}
+}
+
+func (m *MemoryAuthorizer) Authorize(ctx context.Context, user string, permissions []string) (bool, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, p := range permissions {
+		if m.groups[p][user] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// PermsAuthorizer asks perms-srv whether users are in permission groups
+type PermsAuthorizer struct {
+	client  client.Client
+	service string
+}
+
+// NewPermsAuthorizer returns an Authorizer calling the perms-srv registered
+// as service, e.g. com.example.srv.perms
+func NewPermsAuthorizer(c client.Client, service string) *PermsAuthorizer {
+	return &PermsAuthorizer{client: c, service: service}
+}
+
+func (p *PermsAuthorizer) Authorize(ctx context.Context, user string, permissions []string) (bool, error) {
+	req := p.client.NewRequest(p.service, "Permissions.Perform", &permissionsRequest{
+		User:            user,
+		PermissionsList: permissions,
+	})
+	rsp := &performResponse{}
+
+	if err := p.client.Call(ctx, req, rsp); err != nil {
+		return false, err
+	}
+
+	return rsp.CanPerform, nil
+}
+
+// permissionsRequest and performResponse are PermissionsRequest and
+// PerformResponse of perms-srv, copied so the args package doesn't depend on
+// it. auth_test.go checks they encode the same.
+type permissionsRequest struct {
+	User            string   `protobuf:"bytes,1,opt,name=User,proto3" json:"User,omitempty"`
+	PermissionsList []string `protobuf:"bytes,2,rep,name=PermissionsList,proto3" json:"PermissionsList,omitempty"`
+}
+
+func (m *permissionsRequest) Reset()         { *m = permissionsRequest{} }
+func (m *permissionsRequest) String() string { return protobuf.CompactTextString(m) }
+func (*permissionsRequest) ProtoMessage()    {}
+
+type performResponse struct {
+	CanPerform bool `protobuf:"varint,1,opt,name=CanPerform,proto3" json:"CanPerform,omitempty"`
+}
+
+func (m *performResponse) Reset()         { *m = performResponse{} }
+func (m *performResponse) String() string { return protobuf.CompactTextString(m) }
+func (*performResponse) ProtoMessage()    {}
+
+// AuthorizerFunc makes a function an Authorizer, for services that decide
+// who can run what themselves
+type AuthorizerFunc func(ctx context.Context, user string, permissions []string) (bool, error)
+
+func (f AuthorizerFunc) Authorize(ctx context.Context, user string, permissions []string) (bool, error) {
+	return f(ctx, user, permissions)
+}
+
+type decisionsKey struct{}
+
+// decision is what the authorizer set on owner decided for a set of
+// permissions
+type decision struct {
+	owner       *Args
+	permissions string
+}
+
+// decisions remembers the decisions made while handling a request, help
+// would otherwise ask again for every subcommand needing the same permissions
+type decisions struct {
+	sync.Mutex
+	made map[decision]bool
+}
+
+// deciding returns ctx with room for the decisions made while handling a
+// request
+func deciding(ctx context.Context) context.Context {
+	return context.WithValue(ctx, decisionsKey{}, &decisions{made: make(map[decision]bool)})
+}
@@ -0,0 +1,176 @@
+package args
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	protobuf "github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/client"
+	"golang.org/x/net/context"
+
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+// countingAuthorizer counts how often it is asked
+type countingAuthorizer struct {
+	Authorizer
+	asked int
+}
+
+func (c *countingAuthorizer) Authorize(ctx context.Context, user string, permissions []string) (bool, error) {
+	c.asked++
+	return c.Authorizer.Authorize(ctx, user, permissions)
+}
+
+func testArgs(auth Authorizer) *Args {
+	ran := func(ctx context.Context, req *proto.ExecRequest) string { return "ran" }
+
+	a := NewArg("role")
+	a.SetAuthorizer(auth)
+	a.Add("list", &Command{Help: "Lists roles", Funcptr: ran})
+	a.Add("add", &Command{Help: "Adds a role", Funcptr: ran, Permissions: []string{"role_admins"}})
+	a.Add("remove", &Command{Help: "Removes a role", Funcptr: ran, Permissions: []string{"role_admins"}})
+
+	g := a.Group("filter", "Manages filters")
+	g.Add("add", &Command{Help: "Adds a filter", Funcptr: ran, Permissions: []string{"filter_admins", "role_admins"}})
+
+	return a
+}
+
+func runAs(a *Args, user string, args ...string) string {
+	rsp := &proto.ExecResponse{}
+	req := &proto.ExecRequest{Sender: "channel:" + user, Args: append([]string{"role"}, args...)}
+	if err := a.Exec(context.Background(), req, rsp); err != nil {
+		return err.Error()
+	}
+	if len(rsp.Error) > 0 {
+		return rsp.Error
+	}
+	return string(rsp.Result)
+}
+
+func TestPermissions(t *testing.T) {
+	auth := NewMemoryAuthorizer()
+	auth.Grant("admin", "role_admins")
+	auth.Grant("filterer", "filter_admins")
+	a := testArgs(auth)
+
+	tests := []struct {
+		user string
+		args []string
+		want string
+	}{
+		{user: "someone", args: []string{"list"}, want: "ran"},
+		{user: "someone", args: []string{"add"}, want: DeniedMessage},
+		{user: "admin", args: []string{"add"}, want: "ran"},
+		{user: "filterer", args: []string{"add"}, want: DeniedMessage},
+		{user: "filterer", args: []string{"filter", "add"}, want: "ran"},
+		{user: "someone", args: []string{"filter", "add"}, want: DeniedMessage},
+	}
+
+	for _, tt := range tests {
+		if got := runAs(a, tt.user, tt.args...); got != tt.want {
+			t.Errorf("%s running %q = %q, want %q", tt.user, tt.args, got, tt.want)
+		}
+	}
+
+	auth.Revoke("admin", "role_admins")
+	if got := runAs(a, "admin", "add"); got != DeniedMessage {
+		t.Errorf("revoked admin running add = %q, want %q", got, DeniedMessage)
+	}
+}
+
+func TestPermissionsWithoutAuthorizer(t *testing.T) {
+	a := testArgs(nil)
+
+	if got := runAs(a, "admin", "add"); got != DeniedMessage {
+		t.Errorf("running add without an authorizer = %q, want %q", got, DeniedMessage)
+	}
+	if got := runAs(a, "admin", "list"); got != "ran" {
+		t.Errorf("running list without an authorizer = %q, want ran", got)
+	}
+}
+
+func TestHelpHidesDenied(t *testing.T) {
+	auth := NewMemoryAuthorizer()
+	auth.Grant("admin", "role_admins")
+	a := testArgs(auth)
+
+	help := runAs(a, "someone")
+	if !strings.Contains(help, "list") || strings.Contains(help, "Adds a role") {
+		t.Errorf("help for someone = %q, want list without add", help)
+	}
+
+	help = runAs(a, "admin")
+	if !strings.Contains(help, "Adds a role") || !strings.Contains(help, "Removes a role") {
+		t.Errorf("help for admin = %q, want add and remove", help)
+	}
+
+	if got := runAs(a, "someone", "help", "add"); got != "not a valid subcommand: add" {
+		t.Errorf("help add for someone = %q, want it to be unknown", got)
+	}
+}
+
+func TestHelpAsksOncePerPermissions(t *testing.T) {
+	auth := &countingAuthorizer{Authorizer: NewMemoryAuthorizer()}
+	a := testArgs(auth)
+
+	runAs(a, "someone")
+
+	// add and remove need the same permissions, the filter group none
+	if auth.asked != 1 {
+		t.Errorf("help asked the authorizer %d times, want 1", auth.asked)
+	}
+}
+
+// permsClient answers Permissions.Perform like perms-srv does, with the
+// encoded response
+type permsClient struct {
+	client.Client
+	service, endpoint string
+	asked             []byte
+	answer            []byte
+}
+
+type permsRequest struct {
+	client.Request
+	service, endpoint string
+	body              interface{}
+}
+
+func (c *permsClient) NewRequest(service, endpoint string, req interface{}, opts ...client.RequestOption) client.Request {
+	return &permsRequest{service: service, endpoint: endpoint, body: req}
+}
+
+func (c *permsClient) Call(ctx context.Context, req client.Request, rsp interface{}, opts ...client.CallOption) error {
+	r := req.(*permsRequest)
+	c.service, c.endpoint = r.service, r.endpoint
+
+	var err error
+	if c.asked, err = protobuf.Marshal(r.body.(protobuf.Message)); err != nil {
+		return err
+	}
+	return protobuf.Unmarshal(c.answer, rsp.(protobuf.Message))
+}
+
+func TestPermsAuthorizer(t *testing.T) {
+	// PerformResponse{CanPerform: true} as perms-srv encodes it
+	c := &permsClient{answer: []byte{0x08, 0x01}}
+	auth := NewPermsAuthorizer(c, "com.example.srv.perms")
+
+	ok, err := auth.Authorize(context.Background(), "u1", []string{"a", "bc"})
+	if err != nil || !ok {
+		t.Fatalf("Authorize() = %v, %v, want true", ok, err)
+	}
+
+	if c.service != "com.example.srv.perms" || c.endpoint != "Permissions.Perform" {
+		t.Errorf("Authorize() called %s %s", c.service, c.endpoint)
+	}
+
+	// PermissionsRequest{User: "u1", PermissionsList: ["a", "bc"]}
+	want := []byte{0x0a, 0x02, 'u', '1', 0x12, 0x01, 'a', 0x12, 0x02, 'b', 'c'}
+	if !bytes.Equal(c.asked, want) {
+		t.Errorf("Authorize() sent % x, want % x", c.asked, want)
+	}
+}
@@ -0,0 +1,109 @@
+package args
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+// sessionSeparator separates the subcommands that asked a follow up from the
+// session of the command in the session id sent to the bot
+const sessionSeparator = "\n"
+
+// errConversationOver answers follow ups the command can't take anymore
+var errConversationOver = errors.New("that question isn't being asked anymore, run the command again")
+
+type followUpKey struct{}
+
+// followUp is what a command asked with Ask, names are the subcommands
+// naming the command like filter add
+type followUp struct {
+	asked   bool
+	names   []string
+	session string
+	timeout time.Duration
+}
+
+// Ask turns the reply of the running command into a question, the user's
+// answer is passed to the Continue handler of the command along with session.
+// The bot decides how long to wait for the answer if timeout is 0.
+func Ask(ctx context.Context, session string, timeout time.Duration) {
+	if f, ok := ctx.Value(followUpKey{}).(*followUp); ok {
+		f.asked, f.session, f.timeout = true, session, timeout
+	}
+}
+
+// asking returns ctx with room for a follow up asked by the command
+func asking(ctx context.Context) (context.Context, *followUp) {
+	f := &followUp{}
+	return context.WithValue(ctx, followUpKey{}, f), f
+}
+
+// asks records that the command named by names is the one running, it gets
+// the answer if it asks something
+func asks(ctx context.Context, names []string) {
+	if f, ok := ctx.Value(followUpKey{}).(*followUp); ok {
+		f.names = names
+	}
+}
+
+// set puts the follow up in rsp if the command asked one
+func (f *followUp) set(rsp *proto.ExecResponse) {
+	if !f.asked {
+		return
+	}
+
+	rsp.FollowUp = &proto.FollowUp{
+		SessionId: strings.Join(f.names, " ") + sessionSeparator + f.session,
+		Timeout:   int64(f.timeout / time.Second),
+	}
+}
+
+// resume passes the answer in req to the command that asked for it
+func (a *Args) resume(ctx context.Context, req *proto.ExecRequest) (string, error) {
+	parts := strings.SplitN(req.SessionId, sessionSeparator, 2)
+	if len(parts) != 2 || len(strings.Fields(parts[0])) == 0 {
+		return "", errConversationOver
+	}
+	names := strings.Fields(parts[0])
+
+	asks(ctx, names)
+	ctx = context.WithValue(ctx, commandKey{}, a.cmdName+" "+parts[0])
+
+	return a.continueAt(ctx, req, names, parts[1])
+}
+
+// continueAt passes the answer in req to the subcommand named by names inside
+// the middleware of a
+func (a *Args) continueAt(ctx context.Context, req *proto.ExecRequest, names []string, session string) (string, error) {
+	return a.around(func(ctx context.Context, req *proto.ExecRequest) (string, error) {
+		cmd, ok := a.argMap[names[0]]
+		if !ok {
+			return "", errConversationOver
+		}
+
+		allowed, err := a.allowed(ctx, req, cmd)
+		if err != nil {
+			return "", failed("error checking permissions: %v", err)
+		}
+		if !allowed {
+			return DeniedMessage, nil
+		}
+
+		if len(names) > 1 {
+			if cmd.Group == nil {
+				return "", errConversationOver
+			}
+			return cmd.Group.continueAt(ctx, req, names[1:], session)
+		}
+
+		if cmd.Continue == nil {
+			return "", errConversationOver
+		}
+		return cmd.Continue(ctx, req, session, req.Text), nil
+	})(ctx, req)
+}
@@ -0,0 +1,64 @@
+package args
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chremoas/chremoas/cmdline"
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+// DefaultPrefix is shown before commands in help when the bot didn't say what
+// the user typed before the command
+var DefaultPrefix = "!"
+
+func prefixOf(req *proto.ExecRequest) string {
+	if len(req.Prefix) > 0 {
+		return req.Prefix
+	}
+	return DefaultPrefix
+}
+
+// describe renders the full help of cmd, path is what names it
+func describe(prefix, path string, cmd *Command) string {
+	use := cmdline.Usage(prefix+path, cmd.schema())
+	if !cmd.declared() {
+		use += " <arguments>"
+	}
+
+	lines := []string{"Usage: " + use}
+	if len(cmd.Help) > 0 {
+		lines = append(lines, cmd.Help)
+	}
+
+	if len(cmd.Args) > 0 {
+		lines = append(lines, "", "Arguments:")
+		for _, a := range cmd.Args {
+			lines = append(lines, fmt.Sprintf("\t%s (%s): %s", a.Name, cmdline.Details(argumentType(a.Type), a.Required, a.Default, a.Choices), a.Help))
+		}
+	}
+
+	if len(cmd.Flags) > 0 {
+		lines = append(lines, "", "Flags:")
+		for _, f := range cmd.Flags {
+			name := "--" + f.Name
+			if len(f.Short) > 0 {
+				name += ", -" + f.Short
+			}
+			lines = append(lines, fmt.Sprintf("\t%s (%s): %s", name, cmdline.Details(argumentType(f.Type), f.Required, f.Default, f.Choices), f.Help))
+		}
+	}
+
+	if len(cmd.Examples) > 0 {
+		lines = append(lines, "", "Examples:")
+		for _, e := range cmd.Examples {
+			lines = append(lines, "\t"+prefix+e)
+		}
+	}
+
+	if len(cmd.Permissions) > 0 {
+		lines = append(lines, "", "Requires: "+strings.Join(cmd.Permissions, " or "))
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
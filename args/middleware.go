@@ -0,0 +1,174 @@
+package args
+
+import (
+	"fmt"
+	"log"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+// HandlerFunc runs a command, an error is sent back to the user instead of
+// the response
+type HandlerFunc func(ctx context.Context, req *proto.ExecRequest) (string, error)
+
+// Middleware wraps the handlers of commands
+type Middleware func(next HandlerFunc) HandlerFunc
+
+type commandKey struct{}
+
+// CommandFrom returns the command being run, like role filter add, for
+// middleware
+func CommandFrom(ctx context.Context) string {
+	name, _ := ctx.Value(commandKey{}).(string)
+	return name
+}
+
+// Use adds middleware around every command of a and its groups, including
+// the help, checking permissions and parsing arguments. Middleware added
+// first runs first, the middleware of groups runs inside the one of the Args
+// they are in. Commands of a group run through both, so middleware added to
+// a group and the Args it is in, like Logging, runs twice for them.
+func (a *Args) Use(mw ...Middleware) {
+	a.middleware = append(a.middleware, mw...)
+}
+
+// around puts the middleware of a around h
+func (a *Args) around(h HandlerFunc) HandlerFunc {
+	for i := len(a.middleware) - 1; i >= 0; i-- {
+		h = a.middleware[i](h)
+	}
+	return h
+}
+
+// Recover turns a panicking command into an error reply
+func Recover() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *proto.ExecRequest) (response string, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("[args] %s panicked: %v\n%s", CommandFrom(ctx), r, debug.Stack())
+					response, err = "", fmt.Errorf("%s failed unexpectedly", CommandFrom(ctx))
+				}
+			}()
+			return next(ctx, req)
+		}
+	}
+}
+
+// Logging logs who ran each command, how long it took and how it went
+func Logging() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *proto.ExecRequest) (string, error) {
+			started := time.Now()
+			response, err := next(ctx, req)
+
+			if err != nil {
+				log.Printf("[args] %s ran %q in %s: %v\n", userOf(req), strings.Join(req.Args, " "), time.Since(started), err)
+			} else {
+				log.Printf("[args] %s ran %q in %s\n", userOf(req), strings.Join(req.Args, " "), time.Since(started))
+			}
+
+			return response, err
+		}
+	}
+}
+
+// Recorder collects metrics of commands
+type Recorder interface {
+	Record(command string, took time.Duration, err error)
+}
+
+// Metrics records how long each command took and whether it failed
+func Metrics(r Recorder) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *proto.ExecRequest) (string, error) {
+			started := time.Now()
+			response, err := next(ctx, req)
+			r.Record(CommandFrom(ctx), time.Since(started), err)
+			return response, err
+		}
+	}
+}
+
+// limitedKey marks requests a rateLimiter already counted
+type limitedKey struct {
+	l *rateLimiter
+}
+
+// RateLimit lets each user run at most n commands per period. A RateLimit
+// used on a group and the Args it is in counts each command once, separate
+// RateLimits each count it. It panics if n or per isn't positive.
+func RateLimit(n int, per time.Duration) Middleware {
+	if n <= 0 || per <= 0 {
+		panic(fmt.Sprintf("args: RateLimit needs a positive number of commands and period, got %d per %s", n, per))
+	}
+
+	l := &rateLimiter{n: n, per: per, recent: make(map[string][]time.Time)}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *proto.ExecRequest) (string, error) {
+			if ctx.Value(limitedKey{l}) != nil {
+				return next(ctx, req)
+			}
+
+			if wait, ok := l.allow(userOf(req), time.Now()); !ok {
+				return "", fmt.Errorf("you're doing that too often, try again in %s", wait.Round(time.Second))
+			}
+			return next(context.WithValue(ctx, limitedKey{l}, true), req)
+		}
+	}
+}
+
+// rateLimiter remembers when users ran their recent commands
+type rateLimiter struct {
+	sync.Mutex
+	n      int
+	per    time.Duration
+	recent map[string][]time.Time
+	swept  time.Time
+}
+
+// allow records that user runs a command at now, or returns how long they
+// have to wait if they ran too many already
+func (l *rateLimiter) allow(user string, now time.Time) (time.Duration, bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.sweep(now)
+
+	var kept []time.Time
+	for _, t := range l.recent[user] {
+		if now.Sub(t) < l.per {
+			kept = append(kept, t)
+		}
+	}
+
+	if len(kept) >= l.n {
+		l.recent[user] = kept
+		return l.per - now.Sub(kept[0]), false
+	}
+
+	l.recent[user] = append(kept, now)
+	return 0, true
+}
+
+// sweep forgets the users that haven't run anything for a period, at most
+// once a period so it doesn't go through everyone on every command
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.swept) < l.per {
+		return
+	}
+	l.swept = now
+
+	for user, times := range l.recent {
+		if len(times) == 0 || now.Sub(times[len(times)-1]) >= l.per {
+			delete(l.recent, user)
+		}
+	}
+}
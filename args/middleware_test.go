@@ -0,0 +1,150 @@
+package args
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+// panickingAuthorizer panics when asked
+type panickingAuthorizer struct{}
+
+func (panickingAuthorizer) Authorize(ctx context.Context, user string, permissions []string) (bool, error) {
+	panic("no permissions service")
+}
+
+func TestRecover(t *testing.T) {
+	a := testArgs(panickingAuthorizer{})
+	a.Add("crash", &Command{Funcptr: func(ctx context.Context, req *proto.ExecRequest) string { panic("boom") }})
+	a.Use(Recover())
+
+	if got := runAs(a, "someone", "crash"); got != "role crash failed unexpectedly" {
+		t.Errorf("running a panicking command = %q, want it to fail", got)
+	}
+	if got := runAs(a, "someone", "add"); got != "role add failed unexpectedly" {
+		t.Errorf("running with a panicking authorizer = %q, want it to fail", got)
+	}
+}
+
+func TestMiddlewareWrapsEverything(t *testing.T) {
+	a := testArgs(NewMemoryAuthorizer())
+
+	var ran []string
+	a.Use(func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *proto.ExecRequest) (string, error) {
+			ran = append(ran, CommandFrom(ctx))
+			return next(ctx, req)
+		}
+	})
+
+	runAs(a, "someone")
+	runAs(a, "someone", "nope")
+	runAs(a, "someone", "filter", "add", "extra")
+
+	want := []string{"role", "role nope", "role filter add"}
+	if strings.Join(ran, ",") != strings.Join(want, ",") {
+		t.Errorf("middleware ran for %q, want %q", ran, want)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	a := testArgs(nil)
+	a.Use(RateLimit(2, time.Hour))
+
+	for i := 0; i < 2; i++ {
+		if got := runAs(a, "someone", "list"); got != "ran" {
+			t.Fatalf("run %d = %q, want ran", i, got)
+		}
+	}
+	if got := runAs(a, "someone", "list"); !strings.Contains(got, "too often") {
+		t.Errorf("third run = %q, want it to be limited", got)
+	}
+	if got := runAs(a, "else", "list"); got != "ran" {
+		t.Errorf("run by someone else = %q, want ran", got)
+	}
+}
+
+func TestRateLimitForgetsIdleUsers(t *testing.T) {
+	l := &rateLimiter{n: 1, per: time.Minute, recent: make(map[string][]time.Time)}
+	now := time.Now()
+
+	l.allow("a", now)
+	l.allow("b", now.Add(30*time.Second))
+	if _, ok := l.allow("b", now.Add(40*time.Second)); ok {
+		t.Errorf("b ran twice in a minute")
+	}
+
+	l.allow("c", now.Add(2*time.Minute))
+	if len(l.recent) != 1 {
+		t.Errorf("rate limiter remembers %d users, want only c", len(l.recent))
+	}
+}
+
+func TestRateLimitPanicsWithoutLimit(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("RateLimit(0, time.Minute) didn't panic")
+		}
+	}()
+	RateLimit(0, time.Minute)
+}
+
+func TestRateLimitOnGroupsCountsOnce(t *testing.T) {
+	ran := func(ctx context.Context, req *proto.ExecRequest) string { return "ran" }
+
+	a := NewArg("role")
+	g := a.Group("filter", "Manages filters")
+	g.Add("add", &Command{Funcptr: ran})
+
+	limit := RateLimit(2, time.Hour)
+	a.Use(limit)
+	g.Use(limit)
+
+	for i := 0; i < 2; i++ {
+		if got := runAs(a, "someone", "filter", "add"); got != "ran" {
+			t.Fatalf("run %d = %q, want ran", i, got)
+		}
+	}
+	if got := runAs(a, "someone", "filter", "add"); !strings.Contains(got, "too often") {
+		t.Errorf("third run = %q, want it to be limited", got)
+	}
+}
+
+func TestExecReturnsFailures(t *testing.T) {
+	a := testArgs(NewMemoryAuthorizer())
+	a.Add("crash", &Command{Funcptr: func(ctx context.Context, req *proto.ExecRequest) string { panic("boom") }})
+	a.Add("count", &Command{Args: []*Arg{{Name: "n", Type: Int}}, Funcptr: func(ctx context.Context, req *proto.ExecRequest) string { return "ran" }})
+
+	var seen []error
+	a.Use(Recover(), func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *proto.ExecRequest) (string, error) {
+			response, err := next(ctx, req)
+			seen = append(seen, err)
+			return response, err
+		}
+	})
+
+	exec := func(args ...string) (*proto.ExecResponse, error) {
+		rsp := &proto.ExecResponse{}
+		err := a.Exec(context.Background(), &proto.ExecRequest{Sender: "c:someone", Args: append([]string{"role"}, args...)}, rsp)
+		return rsp, err
+	}
+
+	if _, err := exec("nope"); err == nil || err.Error() != "not a valid subcommand: nope" {
+		t.Errorf("Exec(nope) error = %v, want not a valid subcommand", err)
+	}
+	if _, err := exec("count", "many"); err == nil || !strings.Contains(err.Error(), "usage: !role count") {
+		t.Errorf("Exec(count many) error = %v, want the usage", err)
+	}
+	if rsp, err := exec("crash"); err != nil || rsp.Error != "role crash failed unexpectedly" {
+		t.Errorf("Exec(crash) = %q, %v, want the failure in the response", rsp.Error, err)
+	}
+
+	if len(seen) != 2 || seen[0] == nil || seen[1] == nil {
+		t.Errorf("middleware saw %v, want both failures", seen)
+	}
+}
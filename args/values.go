@@ -0,0 +1,211 @@
+package args
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/chremoas/chremoas/cmdline"
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+// Type is the type of the value of an argument or flag
+type Type int
+
+const (
+	String Type = iota
+	Int
+	Bool
+	Duration
+	// Enum values must be one of the Choices
+	Enum
+)
+
+func (t Type) String() string {
+	switch t {
+	case Int:
+		return "int"
+	case Bool:
+		return "bool"
+	case Duration:
+		return "duration"
+	case Enum:
+		return "enum"
+	default:
+		return "string"
+	}
+}
+
+// Arg is a positional argument of a command
+type Arg struct {
+	Name     string
+	Help     string
+	Type     Type
+	Required bool
+	Default  string
+	Choices  []string
+	// Variadic takes the rest of the arguments, only the last one can be
+	Variadic bool
+}
+
+// Flag is a --flag of a command, bool flags don't take a value
+type Flag struct {
+	Name     string
+	Short    string
+	Help     string
+	Type     Type
+	Required bool
+	Default  string
+	Choices  []string
+}
+
+// Values are the arguments and flags of a command after parsing, keyed by
+// name
+type Values struct {
+	values map[string][]string
+	given  map[string]bool
+}
+
+// Has reports whether the user gave name rather than it being defaulted
+func (v *Values) Has(name string) bool {
+	return v.given[name]
+}
+
+// String returns the value of name, or the first one of a variadic argument
+func (v *Values) String(name string) string {
+	if values := v.values[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Strings returns the values of a variadic argument
+func (v *Values) Strings(name string) []string {
+	return v.values[name]
+}
+
+func (v *Values) Int(name string) int64 {
+	i, _ := strconv.ParseInt(v.String(name), 10, 64)
+	return i
+}
+
+func (v *Values) Bool(name string) bool {
+	b, _ := strconv.ParseBool(v.String(name))
+	return b
+}
+
+func (v *Values) Duration(name string) time.Duration {
+	d, _ := time.ParseDuration(v.String(name))
+	return d
+}
+
+func (v *Values) set(name string, given bool, values ...string) {
+	v.values[name] = append(v.values[name], values...)
+	v.given[name] = v.given[name] || given
+}
+
+// parse checks args, the ones after the subcommand, against the arguments
+// and flags of cmd. Commands that don't declare any take anything.
+func parse(cmd *Command, args []string) (*Values, error) {
+	v := &Values{
+		values: make(map[string][]string),
+		given:  make(map[string]bool),
+	}
+
+	if !cmd.declared() {
+		return v, nil
+	}
+
+	var positional []string
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		if arg == "--" {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+
+		if !cmdline.IsFlag(arg) {
+			positional = append(positional, arg)
+			continue
+		}
+
+		name, value, hasValue := cmdline.SplitFlag(arg)
+		f := cmd.flag(name)
+		if f == nil {
+			return nil, fmt.Errorf("unknown flag %s", arg)
+		}
+
+		if f.Type == Bool && !hasValue {
+			value, hasValue = "true", true
+		}
+
+		if !hasValue {
+			if i+1 == len(args) {
+				return nil, fmt.Errorf("flag %s needs a value", arg)
+			}
+			i++
+			value = args[i]
+		}
+
+		if err := cmdline.Check(argumentType(f.Type), f.Choices, value); err != nil {
+			return nil, fmt.Errorf("flag %s: %v", arg, err)
+		}
+		v.set(f.Name, true, value)
+	}
+
+	for _, f := range cmd.Flags {
+		switch {
+		case v.given[f.Name]:
+		case f.Required:
+			return nil, fmt.Errorf("missing flag --%s", f.Name)
+		case len(f.Default) > 0:
+			v.set(f.Name, false, f.Default)
+		}
+	}
+
+	for i, a := range cmd.Args {
+		if i >= len(positional) {
+			if a.Required {
+				return nil, fmt.Errorf("missing argument <%s>", a.Name)
+			}
+			if len(a.Default) > 0 {
+				v.set(a.Name, false, a.Default)
+			}
+			continue
+		}
+
+		values := positional[i : i+1]
+		if a.Variadic {
+			values = positional[i:]
+		}
+
+		for _, value := range values {
+			if err := cmdline.Check(argumentType(a.Type), a.Choices, value); err != nil {
+				return nil, fmt.Errorf("argument <%s>: %v", a.Name, err)
+			}
+		}
+		v.set(a.Name, true, values...)
+	}
+
+	if l := len(cmd.Args); len(positional) > l && (l == 0 || !cmd.Args[l-1].Variadic) {
+		return nil, errors.New("too many arguments")
+	}
+
+	return v, nil
+}
+
+func argumentType(t Type) proto.ArgumentType {
+	switch t {
+	case Int:
+		return proto.ArgumentType_INT
+	case Bool:
+		return proto.ArgumentType_BOOL
+	case Duration:
+		return proto.ArgumentType_DURATION
+	default:
+		return proto.ArgumentType_STRING
+	}
+}
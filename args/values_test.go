@@ -0,0 +1,144 @@
+package args
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+func testCommand() *Command {
+	return &Command{
+		Args: []*Arg{
+			{Name: "name", Required: true},
+			{Name: "count", Type: Int, Default: "1"},
+			{Name: "rest", Variadic: true},
+		},
+		Flags: []*Flag{
+			{Name: "force", Short: "f", Type: Bool},
+			{Name: "for", Type: Duration, Default: "1h"},
+			{Name: "colour", Type: Enum, Choices: []string{"red", "blue"}},
+		},
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		args   []string
+		values map[string][]string
+		given  []string
+		err    string
+	}{
+		{
+			args:   []string{"foo"},
+			values: map[string][]string{"name": {"foo"}, "count": {"1"}, "for": {"1h"}},
+			given:  []string{"name"},
+		},
+		{
+			args:   []string{"foo", "-3", "a", "b"},
+			values: map[string][]string{"name": {"foo"}, "count": {"-3"}, "rest": {"a", "b"}, "for": {"1h"}},
+			given:  []string{"name", "count", "rest"},
+		},
+		{
+			args:   []string{"-f", "--for", "30m", "foo", "--colour=red"},
+			values: map[string][]string{"name": {"foo"}, "count": {"1"}, "force": {"true"}, "for": {"30m"}, "colour": {"red"}},
+			given:  []string{"name", "force", "for", "colour"},
+		},
+		{
+			args:   []string{"--force=false", "--", "--foo"},
+			values: map[string][]string{"name": {"--foo"}, "count": {"1"}, "force": {"false"}, "for": {"1h"}},
+			given:  []string{"name", "force"},
+		},
+		{args: nil, err: "missing argument <name>"},
+		{args: []string{"foo", "many"}, err: "argument <count>: many is not a valid int"},
+		{args: []string{"foo", "--colour", "green"}, err: "flag --colour: green is not one of red, blue"},
+		{args: []string{"foo", "--for"}, err: "flag --for needs a value"},
+		{args: []string{"foo", "--nope"}, err: "unknown flag --nope"},
+	}
+
+	for _, tt := range tests {
+		v, err := parse(testCommand(), tt.args)
+		if len(tt.err) > 0 {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("parse(%q) error = %v, want %s", tt.args, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parse(%q) error = %v", tt.args, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(v.values, tt.values) {
+			t.Errorf("parse(%q) = %q, want %q", tt.args, v.values, tt.values)
+		}
+		for _, name := range tt.given {
+			if !v.Has(name) {
+				t.Errorf("parse(%q) doesn't have %s", tt.args, name)
+			}
+		}
+	}
+}
+
+func TestParseTooMany(t *testing.T) {
+	cmd := &Command{Args: []*Arg{{Name: "name"}}}
+	if _, err := parse(cmd, []string{"a", "b"}); err == nil || err.Error() != "too many arguments" {
+		t.Errorf("parse() error = %v, want too many arguments", err)
+	}
+}
+
+func TestValues(t *testing.T) {
+	v, err := parse(testCommand(), []string{"foo", "3", "--for", "90s", "-f"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v.String("name") != "foo" || v.Int("count") != 3 || !v.Bool("force") || v.Duration("for") != 90*time.Second {
+		t.Errorf("unexpected values %q", v.values)
+	}
+	if v.Has("colour") || len(v.Strings("rest")) != 0 {
+		t.Errorf("unexpected values %q", v.values)
+	}
+}
+
+func TestAddChecksDeclarations(t *testing.T) {
+	tests := []struct {
+		cmd *Command
+		err string
+	}{
+		{cmd: &Command{Args: []*Arg{{Name: "a", Variadic: true}, {Name: "b"}}}, err: "variadic but not the last one"},
+		{cmd: &Command{Args: []*Arg{{Name: "a", Type: Int, Default: "one"}}}, err: "invalid default"},
+		{cmd: &Command{Flags: []*Flag{{Name: "c", Type: Enum, Choices: []string{"x"}, Default: "y"}}}, err: "invalid default"},
+		{cmd: &Command{Args: []*Arg{{Name: "d", Type: Enum}}}, err: "enum without choices"},
+		{cmd: &Command{Flags: []*Flag{{Name: "e", Type: Enum}}}, err: "enum without choices"},
+	}
+
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				r := recover()
+				if r == nil || !strings.Contains(r.(string), tt.err) {
+					t.Errorf("Add() panicked with %v, want %s", r, tt.err)
+				}
+			}()
+			NewArg("test").Add("cmd", tt.cmd)
+		}()
+	}
+}
+
+func TestExecWithoutArgs(t *testing.T) {
+	a := NewArg("test")
+	a.Add("cmd", &Command{Funcptr: func(ctx context.Context, req *proto.ExecRequest) string { return "ran" }})
+
+	rsp := &proto.ExecResponse{}
+	if err := a.Exec(context.Background(), &proto.ExecRequest{}, rsp); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(rsp.Result), "Subcommands") {
+		t.Errorf("Exec() = %q, want the help", rsp.Result)
+	}
+}
@@ -0,0 +1,206 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// aliasTable maps command aliases to the command and leading arguments they
+// expand to. Aliases added or removed at runtime are saved to file if there
+// is one. It is safe for concurrent use.
+type aliasTable struct {
+	sync.RWMutex
+	aliases map[string][]string
+	file    string
+	// changes made at runtime, removed aliases map to nil
+	changes map[string][]string
+}
+
+// newAliasTable parses the configured aliases and applies the changes saved
+// in file on top of them. spec is a JSON object of names and command lines
+// like {"r": "role"}, or the older form "name=command args,name=command".
+func newAliasTable(spec, file string) (*aliasTable, error) {
+	a := &aliasTable{
+		aliases: make(map[string][]string),
+		file:    file,
+		changes: make(map[string][]string),
+	}
+
+	configured, err := parseAliases(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, line := range configured {
+		expansion, err := tokenize(line)
+		if err != nil {
+			return nil, fmt.Errorf("alias %s: %v", name, err)
+		}
+		if len(expansion) == 0 {
+			return nil, fmt.Errorf("alias %s has no command", name)
+		}
+		a.aliases[name] = expansion
+	}
+
+	if len(file) == 0 {
+		return a, nil
+	}
+
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return a, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &a.changes); err != nil {
+		return nil, fmt.Errorf("aliases file %s: %v", file, err)
+	}
+
+	for name, expansion := range a.changes {
+		if len(expansion) == 0 {
+			delete(a.aliases, name)
+			continue
+		}
+		a.aliases[name] = expansion
+	}
+
+	return a, nil
+}
+
+// parseAliases returns the command lines of the aliases in spec
+func parseAliases(spec string) (map[string]string, error) {
+	aliases := make(map[string]string)
+
+	if strings.HasPrefix(strings.TrimSpace(spec), "{") {
+		if err := json.Unmarshal([]byte(spec), &aliases); err != nil {
+			return nil, fmt.Errorf("aliases: %v", err)
+		}
+		return aliases, nil
+	}
+
+	for _, entry := range strings.Split(spec, ",") {
+		if len(strings.TrimSpace(entry)) == 0 {
+			continue
+		}
+
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("alias %q must be in the form name=command", entry)
+		}
+
+		aliases[strings.TrimSpace(parts[0])] = parts[1]
+	}
+
+	return aliases, nil
+}
+
+// expand replaces an alias in the first argument with what it stands for
+func (a *aliasTable) expand(args []string) ([]string, bool) {
+	if len(args) == 0 {
+		return args, false
+	}
+
+	a.RLock()
+	expansion, ok := a.aliases[args[0]]
+	a.RUnlock()
+
+	if !ok {
+		return args, false
+	}
+
+	expanded := make([]string, 0, len(expansion)+len(args)-1)
+	expanded = append(expanded, expansion...)
+	return append(expanded, args[1:]...), true
+}
+
+func (a *aliasTable) set(name string, expansion []string) error {
+	a.Lock()
+	defer a.Unlock()
+
+	a.aliases[name] = expansion
+	a.changes[name] = expansion
+	return a.save()
+}
+
+func (a *aliasTable) remove(name string) (bool, error) {
+	a.Lock()
+	defer a.Unlock()
+
+	if _, ok := a.aliases[name]; !ok {
+		return false, nil
+	}
+	delete(a.aliases, name)
+	a.changes[name] = nil
+	return true, a.save()
+}
+
+// save writes the changes made at runtime to the aliases file, callers must
+// hold the lock. The file is replaced in one go so a crash can't leave half
+// of it behind.
+func (a *aliasTable) save() error {
+	if len(a.file) == 0 {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(a.changes, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(a.file), filepath.Base(a.file)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), a.file)
+}
+
+// names returns the aliases in alphabetical order
+func (a *aliasTable) names() []string {
+	a.RLock()
+	defer a.RUnlock()
+
+	var names []string
+	for name := range a.aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// help lists the aliases and what they expand to
+func (a *aliasTable) help() []string {
+	var lines []string
+
+	for _, name := range a.names() {
+		a.RLock()
+		expansion := a.aliases[name]
+		a.RUnlock()
+
+		if expansion == nil {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s - alias for %s", name, strings.Join(expansion, " ")))
+	}
+
+	return lines
+}
@@ -0,0 +1,103 @@
+package bot
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/micro/go-bot/input"
+)
+
+func TestParseAliases(t *testing.T) {
+	tests := []struct {
+		spec    string
+		aliases map[string]string
+		err     bool
+	}{
+		{spec: `{"r": "role", "myroles": "role list_member_roles"}`, aliases: map[string]string{"r": "role", "myroles": "role list_member_roles"}},
+		{spec: "r=role,myroles=role list_member_roles", aliases: map[string]string{"r": "role", "myroles": "role list_member_roles"}},
+		{spec: "", aliases: map[string]string{}},
+		{spec: "r", err: true},
+		{spec: `{"r": ["role"]}`, err: true},
+	}
+
+	for _, tt := range tests {
+		aliases, err := parseAliases(tt.spec)
+		if (err != nil) != tt.err {
+			t.Errorf("parseAliases(%q) error = %v, want error %v", tt.spec, err, tt.err)
+			continue
+		}
+		if !tt.err && !reflect.DeepEqual(aliases, tt.aliases) {
+			t.Errorf("parseAliases(%q) = %v, want %v", tt.spec, aliases, tt.aliases)
+		}
+	}
+}
+
+func TestAliasesSaved(t *testing.T) {
+	dir, err := ioutil.TempDir("", "aliases")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "aliases.json")
+	spec := `{"r": "role", "s": "sig list"}`
+
+	a, err := newAliasTable(spec, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.set("m", []string{"role", "list_member_roles"}); err != nil {
+		t.Fatal(err)
+	}
+	if removed, err := a.remove("s"); !removed || err != nil {
+		t.Fatalf("remove() = %v, %v", removed, err)
+	}
+
+	// the changes are applied on top of the configured aliases again
+	a, err = newAliasTable(spec, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := a.names(); !reflect.DeepEqual(names, []string{"m", "r"}) {
+		t.Errorf("names() after reload = %v, want [m r]", names)
+	}
+	if args, ok := a.expand([]string{"m", "x"}); !ok || !reflect.DeepEqual(args, []string{"role", "list_member_roles", "x"}) {
+		t.Errorf("expand() = %v, %v", args, ok)
+	}
+
+	// nothing but the file is left behind
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("%d files in the aliases directory, want 1", len(files))
+	}
+}
+
+func TestAliasReservedNames(t *testing.T) {
+	a, err := newAliasTable("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := &bot{aliases: a, admins: map[string]bool{"u1": true}}
+	b.internal = b.botCommands()
+
+	ev := input.Event{From: "c1:u1"}
+	for _, name := range []string{"cancel", "alias", "help"} {
+		if _, err := b.alias(ev, []string{"alias", "add", name, "role"}); err == nil {
+			t.Errorf("alias add %s succeeded", name)
+		}
+	}
+
+	if _, err := b.alias(ev, []string{"alias", "add", "r", "role"}); err != nil {
+		t.Errorf("alias add r: %v", err)
+	}
+	if args, ok := a.expand([]string{"r"}); !ok || !reflect.DeepEqual(args, []string{"role"}) {
+		t.Errorf("expand() = %v, %v", args, ok)
+	}
+}
@@ -2,10 +2,11 @@
 package bot
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
-	"regexp"
 	"sort"
 	"strings"
 	"sync"
@@ -17,7 +18,9 @@ import (
 	"github.com/micro/go-bot/command"
 	"github.com/micro/go-bot/input"
 
+	"github.com/chremoas/chremoas/events"
 	proto "github.com/chremoas/chremoas/proto"
+	"github.com/chremoas/chremoas/render"
 
 	"github.com/chremoas/services-common/config"
 	"github.com/micro/go-micro/registry"
@@ -32,10 +35,31 @@ type bot struct {
 	ctx     *cli.Context
 	service micro.Service
 
+	workers     int
+	queueDepth  int
+	timeout     time.Duration
+	noticeAfter time.Duration
+	attachAfter int
+	maxChain    int
+	notifiers   map[string]bool
+	channels    map[string]string
+	tokens      map[string]string
+
 	sync.RWMutex
-	inputs   map[string]input.Input
-	commands map[string]command.Command
-	services map[string]string
+	inputs        map[string]input.Input
+	commands      commandTable
+	services      map[string]*serviceInfo
+	internal      map[string]*botCommand
+	aliases       *aliasTable
+	admins        map[string]bool
+	quiet         map[string]bool
+	inflight      *inflight
+	jobs          *jobTracker
+	components    *componentRegistry
+	conversations *conversations
+	conns         map[string]input.Conn
+
+	deliveries chan delivery
 }
 
 var (
@@ -81,43 +105,261 @@ var DefaultFlags = []cli.Flag{
 		Value:  "/etc/auth-srv/application.yaml",
 		EnvVar: "CONFIGURATION_FILE",
 	},
+	cli.IntFlag{
+		Name:   "workers",
+		EnvVar: "MICRO_BOT_WORKERS",
+		Usage:  "Number of commands run concurrently per input",
+		Value:  4,
+	},
+	cli.IntFlag{
+		Name:   "queue_depth",
+		EnvVar: "MICRO_BOT_QUEUE_DEPTH",
+		Usage:  "Number of commands queued per input before the bot reports it is busy",
+		Value:  32,
+	},
+	cli.IntFlag{
+		Name:   "attach_after",
+		EnvVar: "MICRO_BOT_ATTACH_AFTER",
+		Usage:  "Send responses longer than this many bytes as a file where the input supports it, 0 to always split them",
+		Value:  8000,
+	},
+	cli.IntFlag{
+		Name:   "command_timeout",
+		EnvVar: "MICRO_BOT_COMMAND_TIMEOUT",
+		Usage:  "Seconds to wait for commands that don't advertise a maximum runtime (default 60)",
+	},
+	cli.IntFlag{
+		Name:   "notice_after",
+		EnvVar: "MICRO_BOT_NOTICE_AFTER",
+		Usage:  "Seconds before telling the user a command that doesn't advertise an expected runtime is still running (default 10)",
+	},
+	cli.IntFlag{
+		Name:   "max_chain",
+		EnvVar: "MICRO_BOT_MAX_CHAIN",
+		Usage:  "Most commands that can be chained with ; and | in one message (default 5)",
+	},
+	cli.StringFlag{
+		Name:   "aliases",
+		EnvVar: "MICRO_BOT_ALIASES",
+		Usage:  `Command aliases as JSON e.g. {"r": "role", "myroles": "role list_member_roles"}, r=role,myroles=role list_member_roles still works`,
+	},
+	cli.StringFlag{
+		Name:   "aliases_file",
+		EnvVar: "MICRO_BOT_ALIASES_FILE",
+		Usage:  "File aliases added and removed with the alias command are saved in, they are lost on restart without it",
+	},
+	cli.StringFlag{
+		Name:   "quiet_channels",
+		EnvVar: "MICRO_BOT_QUIET_CHANNELS",
+		Usage:  "Channel IDs where unknown commands are ignored instead of answered with suggestions (seperated by ,)",
+	},
+	cli.StringFlag{
+		Name:   "admins",
+		EnvVar: "MICRO_BOT_ADMINS",
+		Usage:  "User IDs allowed to manage the bot (seperated by ,)",
+	},
+	cli.StringFlag{
+		Name:   "notifiers",
+		EnvVar: "MICRO_BOT_NOTIFIERS",
+		Usage:  "Services allowed to post messages through the Notify service (seperated by ,)",
+	},
+	cli.StringFlag{
+		Name:   "notify_channels",
+		EnvVar: "MICRO_BOT_NOTIFY_CHANNELS",
+		Usage:  "Channel aliases for the Notify service e.g. announcements=1234567890,ops=2234567890",
+	},
+	cli.StringFlag{
+		Name:   "service_tokens",
+		EnvVar: "MICRO_BOT_SERVICE_TOKENS",
+		Usage:  "Tokens services send to authenticate to the Notify and Jobs services e.g. net.4amlunch.dev.srv.auth=s3cret",
+	},
 }
 
-var App *cli.App
+// busyMessage is sent when an input's command queue is full
+var busyMessage = "The bot is busy right now, please try again in a moment."
 
-func help(commands map[string]command.Command, serviceCommands []string) command.Command {
-	usage := "help"
-	desc := "Displays help for all known commands"
+// errCancelled is returned for commands cancelled by the user
+var errCancelled = errors.New("cancelled")
 
-	var cmds []command.Command
+var App *cli.App
 
-	for _, cmd := range commands {
-		cmds = append(cmds, cmd)
-	}
+func (b *bot) help(commands commandTable, serviceCommands []string) command.Command {
+	usage := "help [command [subcommand...]]"
+	desc := "Displays help for all known commands, or the details of one"
+
+	cmds := commands.commands()
 
 	sort.Sort(sortedCommands{cmds})
 
 	return command.NewCommand("help", usage, desc, func(args ...string) ([]byte, error) {
+		if len(args) > 1 {
+			return b.helpFor(args[1:])
+		}
+
 		response := []string{"\n"}
 		for _, cmd := range cmds {
 			response = append(response, fmt.Sprintf("%s - %s", cmd.Usage(), cmd.Description()))
 		}
+		response = append(response, b.botCommandHelp()...)
 		response = append(response, serviceCommands...)
+		response = append(response, b.aliases.help()...)
 		return []byte(strings.Join(response, "\n")), nil
 	})
 }
 
-func newBot(ctx *cli.Context, inputs map[string]input.Input, commands map[string]command.Command, service micro.Service) *bot {
-	commands["^help$"] = help(commands, nil)
+func newBot(ctx *cli.Context, inputs map[string]input.Input, commands map[string]command.Command, service micro.Service) (*bot, error) {
+	timeout := time.Duration(ctx.Int("command_timeout")) * time.Second
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	noticeAfter := time.Duration(ctx.Int("notice_after")) * time.Second
+	if noticeAfter <= 0 {
+		noticeAfter = DefaultNoticeAfter
+	}
+
+	maxChain := ctx.Int("max_chain")
+	if maxChain <= 0 {
+		maxChain = DefaultMaxChain
+	}
+
+	aliases, err := newAliasTable(ctx.String("aliases"), ctx.String("aliases_file"))
+	if err != nil {
+		return nil, fmt.Errorf("error loading aliases: %v", err)
+	}
+
+	admins := make(map[string]bool)
+	for _, admin := range strings.Split(ctx.String("admins"), ",") {
+		if len(admin) > 0 {
+			admins[admin] = true
+		}
+	}
+
+	quiet := make(map[string]bool)
+	for _, channel := range strings.Split(ctx.String("quiet_channels"), ",") {
+		if len(channel) > 0 {
+			quiet[channel] = true
+		}
+	}
 
-	return &bot{
-		ctx:      ctx,
-		exit:     make(chan bool),
-		service:  service,
-		commands: commands,
-		inputs:   inputs,
-		services: make(map[string]string),
+	notifiers := make(map[string]bool)
+	for _, service := range strings.Split(ctx.String("notifiers"), ",") {
+		if len(service) > 0 {
+			notifiers[service] = true
+		}
 	}
+
+	channels, err := parsePairs(ctx.String("notify_channels"))
+	if err != nil {
+		return nil, fmt.Errorf("error loading notify channels: %v", err)
+	}
+
+	tokens, err := parsePairs(ctx.String("service_tokens"))
+	if err != nil {
+		return nil, fmt.Errorf("error loading service tokens: %v", err)
+	}
+
+	b := &bot{
+		ctx:           ctx,
+		exit:          make(chan bool),
+		service:       service,
+		workers:       ctx.Int("workers"),
+		queueDepth:    ctx.Int("queue_depth"),
+		timeout:       timeout,
+		noticeAfter:   noticeAfter,
+		attachAfter:   ctx.Int("attach_after"),
+		maxChain:      maxChain,
+		notifiers:     notifiers,
+		channels:      channels,
+		tokens:        tokens,
+		inputs:        inputs,
+		services:      make(map[string]*serviceInfo),
+		aliases:       aliases,
+		admins:        admins,
+		quiet:         quiet,
+		inflight:      newInflight(),
+		jobs:          newJobTracker(),
+		components:    newComponentRegistry(),
+		conversations: newConversations(),
+		conns:         make(map[string]input.Conn),
+		deliveries:    make(chan delivery, EventQueueDepth),
+	}
+	b.internal = b.botCommands()
+
+	for _, name := range aliases.names() {
+		if b.reserved(name) {
+			return nil, fmt.Errorf("alias %s is a bot command", name)
+		}
+	}
+
+	table := newCommandTable(commands)
+	table.warnOverlaps()
+	b.commands = table
+	b.setHelp(nil)
+
+	return b, nil
+}
+
+// parsePairs parses settings in the form name=value,name=value
+func parsePairs(spec string) (map[string]string, error) {
+	pairs := make(map[string]string)
+
+	for _, entry := range strings.Split(spec, ",") {
+		if len(strings.TrimSpace(entry)) == 0 {
+			continue
+		}
+
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || len(strings.TrimSpace(parts[0])) == 0 || len(strings.TrimSpace(parts[1])) == 0 {
+			return nil, fmt.Errorf("%q must be in the form name=value", entry)
+		}
+
+		pairs[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	return pairs, nil
+}
+
+// replyTo builds an event replying to ev with data
+func replyTo(ev input.Event, data []byte) *input.Event {
+	return &input.Event{
+		Meta: ev.Meta,
+		From: ev.To,
+		To:   ev.From,
+		Type: input.TextEvent,
+		Data: data,
+	}
+}
+
+// messageTo builds an event sending data to channel that isn't a reply to
+// anything
+func messageTo(channel string, data []byte) *input.Event {
+	return &input.Event{
+		Meta: make(map[string]interface{}),
+		To:   channel + ":",
+		Type: input.TextEvent,
+		Data: data,
+	}
+}
+
+// reply sends data back to wherever ev came from
+func reply(c input.Conn, ev input.Event, data []byte) error {
+	return c.Send(replyTo(ev, data))
+}
+
+// respond sends the output of a command back to wherever ev came from, rich
+// responses are sent as such if the connection supports them
+func respond(c input.Conn, ev input.Event, data []byte, rich *proto.RichResponse) error {
+	return send(c, replyTo(ev, data), rich)
+}
+
+// send sends e, rich responses are sent as such if the connection supports
+// them
+func send(c input.Conn, e *input.Event, rich *proto.RichResponse) error {
+	if rs, ok := c.(richSender); ok && rich != nil {
+		return rs.SendRich(e, rich)
+	}
+	return c.Send(e)
 }
 
 func (b *bot) loop(io input.Input) {
@@ -137,36 +379,148 @@ func (b *bot) loop(io input.Input) {
 	}
 }
 
-func (b *bot) process(c input.Conn, ev input.Event) error {
-	args := strings.Split(string(ev.Data), " ")
-	if len(args) == 0 {
+func (b *bot) process(name string, c input.Conn, ev input.Event) error {
+	if ev.Type == input.EventType(events.Component) {
+		return b.interactEvent(name, c, ev)
+	}
+
+	// the user's answer to a follow up isn't a command
+	if conv, ok := b.conversations.take(name, ev.From); ok {
+		return b.answer(name, c, ev, conv)
+	}
+
+	// a number answers the sender's last prompt on inputs without components
+	if choice, ok := b.chosen(name, c, ev); ok {
+		var values []string
+		if len(choice.Value) > 0 {
+			values = []string{choice.Value}
+		}
+		return b.interact(name, c, ev, choice.ComponentID, values)
+	}
+
+	steps, err := parseChain(string(ev.Data))
+	if err != nil {
+		return reply(c, ev, []byte("error parsing cmd: "+err.Error()))
+	}
+
+	if len(steps) == 0 {
 		return nil
 	}
 
-	b.RLock()
-	defer b.RUnlock()
+	// a single command ending in a ? of its own asks for suggestions
+	if len(steps) == 1 {
+		if output, ok := b.completeText(name, ev, steps[0]); ok {
+			return reply(c, ev, output)
+		}
+	}
 
-	// try built in command
-	for pattern, cmd := range b.commands {
-		// skip if it doesn't match
-		if m, err := regexp.Match(pattern, ev.Data); err != nil || !m {
+	if len(steps) > b.maxChain {
+		return reply(c, ev, []byte(fmt.Sprintf("error parsing cmd: at most %d commands can be chained", b.maxChain)))
+	}
+
+	var (
+		output []byte
+		rich   *proto.RichResponse
+		failed bool
+	)
+
+	for i, s := range steps {
+		// a failed command stops the pipeline it is part of
+		if s.pipe && failed {
 			continue
 		}
 
+		var stdin []byte
+		if s.pipe {
+			stdin = output
+			if len(stdin) == 0 && rich != nil {
+				stdin = []byte(render.Text(rich))
+			}
+		}
+
+		output, rich, err = b.execute(name, c, ev, s, stdin)
+		failed = err != nil && err != errStreamed
+
+		// only the last command of a pipeline replies
+		if s.piped && !failed {
+			continue
+		}
+
+		_, unknown := err.(*unknownCommandError)
+
+		switch {
+		case err == errCancelled:
+			// cancelled from this channel, the cancel command already
+			// replied
+			continue
+		case err == errStreamed:
+			continue
+		case unknown && len(steps) == 1 && b.isQuiet(ev):
+			continue
+		case err != nil && len(steps) > 1:
+			output = []byte(fmt.Sprintf("%s (command %d of %d)", err.Error(), i+1, len(steps)))
+		case err != nil:
+			output = []byte(err.Error())
+		}
+
+		if err := respond(c, ev, output, rich); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// execute runs a single command and returns its output. stdin is the output
+// of the previous command when the command is part of a pipeline.
+func (b *bot) execute(name string, c input.Conn, ev input.Event, s step, stdin []byte) ([]byte, *proto.RichResponse, error) {
+	args := s.args
+
+	// patterns of built in commands match what the user typed unless it
+	// was an alias
+	text := []byte(s.text)
+	if expanded, ok := b.aliases.expand(args); ok {
+		args = expanded
+		text = []byte(strings.Join(args, " "))
+	}
+
+	// piped output is passed as the last argument to services that can't
+	// read it from stdin
+	withStdin := args
+	if s.pipe {
+		withStdin = append(append([]string(nil), args...), strings.TrimSpace(string(stdin)))
+	}
+
+	// take a snapshot so the lock isn't held while commands run
+	b.RLock()
+	commands := b.commands
+	info, isService := b.services[Namespace+"."+args[0]]
+	b.RUnlock()
+
+	// commands of the bot don't read piped output
+	_, isInternal := b.internal[args[0]]
+	_, isBuiltIn := commands.match(text)
+	if s.pipe && (isInternal || isBuiltIn) {
+		return nil, nil, fmt.Errorf("error executing cmd: %s can't be piped into", args[0])
+	}
+
+	// try commands implemented by the bot
+	if cmd, ok := b.internal[args[0]]; ok {
+		rsp, err := cmd.exec(ev, args)
+		if err != nil {
+			return nil, nil, errors.New("error executing cmd: " + err.Error())
+		}
+		return rsp, nil, nil
+	}
+
+	// try built in command
+	if cmd, ok := commands.match(text); ok {
 		// matched, exec command
 		rsp, err := cmd.Exec(args...)
 		if err != nil {
-			rsp = []byte("error executing cmd: " + err.Error())
+			return nil, nil, errors.New("error executing cmd: " + err.Error())
 		}
-
-		// send response
-		return c.Send(&input.Event{
-			Meta: ev.Meta,
-			From: ev.To,
-			To:   ev.From,
-			Type: input.TextEvent,
-			Data: rsp,
-		})
+		return rsp, nil, nil
 	}
 
 	// no built in match
@@ -174,37 +528,107 @@ func (b *bot) process(c input.Conn, ev input.Event) error {
 	service := Namespace + "." + args[0]
 
 	// is there a service for the command?
-	if _, ok := b.services[service]; !ok {
-		return nil
+	if !isService {
+		return nil, nil, b.unknownCommand(args[0])
+	}
+
+	if err := validate(info.spec, args); err != nil {
+		return nil, nil, errors.New("error parsing cmd: " + err.Error())
+	}
+
+	// services that describe their arguments read piped output from stdin
+	if info.spec.Schema == nil {
+		args = withStdin
 	}
 
 	// make service request
-	req := b.service.Client().NewRequest(service, "Command.Exec", &proto.ExecRequest{
+	exec := &proto.ExecRequest{
 		Sender: ev.From,
 		Args:   args,
-	})
-	rsp := &proto.ExecResponse{}
+		Text:   s.text,
+		Stdin:  stdin,
+	}
+	setInvocation(exec, name, ev)
+
+	timeout := info.timeoutOr(b.timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
-	var response []byte
+	// track the request so it can be cancelled
+	id := b.inflight.add(ev.From, strings.Join(args, " "), cancel)
+	defer b.inflight.remove(id)
+
+	// let the user know if the command is taking a while
+	stop := stillWorking(c, ev, info.noticeAfterOr(b.noticeAfter))
+
+	var (
+		rsp      *proto.ExecResponse
+		err      error
+		progress *streamReporter
+	)
 
 	// call service
-	ctx, _ := context.WithTimeout(context.Background(), time.Minute)
-	if err := b.service.Client().Call(ctx, req, rsp); err != nil {
-		response = []byte("error executing cmd: " + err.Error())
-	} else if len(rsp.Error) > 0 {
-		response = []byte("error executing cmd: " + rsp.Error)
+	if info.supports(proto.CapabilityStream) {
+		// updates are only shown when the output goes to the user
+		if !s.piped {
+			progress = newStreamReporter(c, ev, name)
+			defer progress.close()
+		}
+
+		rsp, err = b.execStream(ctx, service, exec, func(u *proto.ExecStreamResponse) {
+			stop()
+			if progress != nil {
+				progress.update(u)
+			}
+		})
 	} else {
-		response = rsp.Result
+		req := b.service.Client().NewRequest(service, "Command.Exec", exec)
+		rsp = &proto.ExecResponse{}
+		err = b.service.Client().Call(ctx, req, rsp)
+	}
+	stop()
+
+	switch {
+	case err != nil && ctx.Err() == context.Canceled:
+		return nil, nil, b.inflight.cancelled(id)
+	case err != nil && ctx.Err() == context.DeadlineExceeded:
+		return nil, nil, fmt.Errorf("%s (%s) didn't finish within %s, giving up", args[0], service, timeout)
+	case err != nil:
+		return nil, nil, errors.New("error executing cmd: " + err.Error())
+	case len(rsp.Error) > 0:
+		return nil, nil, errors.New("error executing cmd: " + rsp.Error)
+	case len(rsp.JobId) > 0:
+		j := &job{
+			id:      rsp.JobId,
+			service: service,
+			command: strings.Join(args, " "),
+			input:   name,
+			ev:      ev,
+			started: time.Now(),
+		}
+		if done := b.jobs.add(j); done != nil {
+			// the service finished the job before the bot got the response
+			return j.result(done), b.components.add(service, name, ev, done.Rich), nil
+		}
+		return []byte(fmt.Sprintf("Started job %s, the result will be posted here when it's done. Use job %s to check on it.", rsp.JobId, rsp.JobId)), nil, nil
 	}
 
-	// send response
-	return c.Send(&input.Event{
-		Meta: ev.Meta,
-		From: ev.To,
-		To:   ev.From,
-		Type: input.TextEvent,
-		Data: response,
-	})
+	if rsp.Rich != nil && !info.supports(proto.CapabilityRich) {
+		log.Printf("[bot] %s sent a rich response without advertising %s\n", service, proto.CapabilityRich)
+	}
+
+	if !s.piped {
+		_, n := findSpec(info.spec, args)
+		b.followUp(name, ev, service, args[:n], info, rsp)
+	}
+
+	rsp.Rich = b.components.add(service, name, ev, rsp.Rich)
+
+	if progress != nil {
+		return progress.finish(rsp)
+	}
+
+	return rsp.Result, rsp.Rich, nil
 }
 
 func (b *bot) run(io input.Input) error {
@@ -215,6 +639,29 @@ func (b *bot) run(io input.Input) error {
 		return err
 	}
 
+	// adapt responses to what the input supports
+	c = newOutputConn(c, io.String(), b.attachAfter)
+
+	// keep track of the connection so jobs can post to it
+	b.Lock()
+	b.conns[io.String()] = c
+	b.Unlock()
+
+	defer func() {
+		b.Lock()
+		if b.conns[io.String()] == c {
+			delete(b.conns, io.String())
+		}
+		b.Unlock()
+	}()
+
+	d := newDispatcher(b.workers, b.queueDepth, func(ev input.Event) {
+		if err := b.process(io.String(), c, ev); err != nil {
+			log.Println("[bot][dispatch] error", io.String(), err)
+		}
+	})
+	defer d.close()
+
 	for {
 		select {
 		case <-b.exit:
@@ -227,17 +674,28 @@ func (b *bot) run(io input.Input) error {
 				return err
 			}
 
-			// only process TextEvent
-			if recvEv.Type != input.TextEvent {
+			// other events go to the services subscribed to them
+			if recvEv.Type != input.TextEvent && recvEv.Type != input.EventType(events.Component) {
+				b.publish(io.String(), recvEv)
 				continue
 			}
 
-			if len(recvEv.Data) == 0 {
+			if recvEv.Type == input.TextEvent && len(recvEv.Data) == 0 {
 				continue
 			}
 
-			if err := b.process(c, recvEv); err != nil {
-				return err
+			if b.isImmediate(recvEv) {
+				if err := b.process(io.String(), c, recvEv); err != nil {
+					log.Println("[bot][loop] error", io.String(), err)
+				}
+				continue
+			}
+
+			if !d.submit(recvEv) {
+				log.Println("[bot][loop] queue full", io.String())
+				if err := reply(c, recvEv, []byte(busyMessage)); err != nil {
+					log.Println("[bot][loop] error", err)
+				}
 			}
 		}
 	}
@@ -258,12 +716,28 @@ func (b *bot) start() error {
 			return err
 		}
 
+		if ci, ok := io.(completionInput); ok {
+			name := io.String()
+			ci.HandleCompletions(func(ev input.Event, text, argument, prefix string) ([]*proto.Suggestion, error) {
+				return b.complete(name, ev, text, argument, prefix)
+			})
+		}
+
 		go b.loop(io)
 	}
 
 	// start watcher
 	go b.watch()
 
+	// deliver chat events
+	workers := b.workers
+	if workers < 1 {
+		workers = 1
+	}
+	for i := 0; i < workers; i++ {
+		go b.deliver()
+	}
+
 	return nil
 }
 
@@ -282,30 +756,32 @@ func (b *bot) stop() error {
 	return nil
 }
 
-func (b *bot) watch() {
-	commands := map[string]command.Command{}
-	services := map[string]string{}
+// setHelp replaces the help command with one listing the current commands
+// and serviceCommands, callers must hold the lock.
+func (b *bot) setHelp(serviceCommands []string) {
+	commands := b.commands.without(helpPattern)
+	b.commands = commands.with(helpPattern, b.help(commands, serviceCommands))
+}
 
-	// copy commands
-	b.RLock()
-	for k, v := range b.commands {
-		commands[k] = v
-	}
-	b.RUnlock()
+func (b *bot) watch() {
+	services := map[string]*serviceInfo{}
 
 	// getHelp retries usage and description from bot service commands
-	getHelp := func(service string) (string, error) {
+	getHelp := func(service string) (*serviceInfo, error) {
 		// is within namespace?
 		if !strings.HasPrefix(service, Namespace) {
-			return "", fmt.Errorf("%s not within namespace", service)
+			return nil, fmt.Errorf("%s not within namespace", service)
 		}
 
 		if p := strings.TrimPrefix(service, Namespace); len(p) == 0 {
-			return "", fmt.Errorf("%s not a service", service)
+			return nil, fmt.Errorf("%s not a service", service)
 		}
 
 		// get command help
-		req := b.service.Client().NewRequest(service, "Command.Help", &proto.HelpRequest{})
+		req := b.service.Client().NewRequest(service, "Command.Help", &proto.HelpRequest{
+			ProtocolVersion: proto.ProtocolVersion,
+			Capabilities:    proto.Capabilities,
+		})
 		rsp := &proto.HelpResponse{}
 
 		count := 0
@@ -316,14 +792,23 @@ func (b *bot) watch() {
 			} else {
 				log.Printf("Retrying client call, count: %d\n", count)
 				if count >= 50 {
-					return "", err
+					return nil, err
 				}
 				count += 1
 				time.Sleep(time.Duration(count) * time.Millisecond)
 			}
 		}
 
-		return fmt.Sprintf("%s - %s", rsp.Usage, rsp.Description), nil
+		info := newServiceInfo(strings.TrimPrefix(service, Namespace+"."), rsp)
+		info.capabilities = negotiate(service, rsp)
+		info.events = subscriptions(b.service.Client().Options().Registry, service)
+
+		if len(info.events) > 0 && !info.supports(proto.CapabilityEvents) {
+			log.Printf("[bot][watch] %s subscribed to events but doesn't handle them\n", service)
+			info.events = nil
+		}
+
+		return info, nil
 	}
 
 	serviceList, err := b.service.Client().Options().Registry.ListServices()
@@ -341,14 +826,17 @@ func (b *bot) watch() {
 			continue
 		}
 		services[service.Name] = h
-		serviceCommands = append(serviceCommands, h)
+		serviceCommands = append(serviceCommands, h.help)
 	}
 
 	b.Lock()
-	b.commands["^help$"] = help(commands, serviceCommands)
-	b.services = services
+	b.setHelp(serviceCommands)
+	b.services = copyServices(services)
 	b.Unlock()
 
+	registered := specsOf(services)
+	b.registerCommands(registered)
+
 	w, err := b.service.Client().Options().Registry.Watch()
 	if err != nil {
 		// log error?
@@ -379,19 +867,24 @@ func (b *bot) watch() {
 				}
 				continue
 			}
-			log.Printf("Response from getHelp: %s\n", h)
+			log.Printf("Response from getHelp: %s\n", h.help)
 			services[res.Service.Name] = h
 		}
 
 		var serviceCommands []string
 		for _, v := range services {
-			serviceCommands = append(serviceCommands, v)
+			serviceCommands = append(serviceCommands, v.help)
 		}
 
 		b.Lock()
-		b.commands["^help$"] = help(commands, serviceCommands)
-		b.services = services
+		b.setHelp(serviceCommands)
+		b.services = copyServices(services)
 		b.Unlock()
+
+		if specs := specsOf(services); !sameSpecs(specs, registered) {
+			b.registerCommands(specs)
+			registered = specs
+		}
 	}
 }
 
@@ -474,7 +967,14 @@ func Run(ctx *cli.Context) {
 	)
 
 	// Start bot
-	b := newBot(ctx, ios, cmds, service)
+	b, err := newBot(ctx, ios, cmds, service)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Register the services the bot hosts
+	proto.RegisterJobsHandler(service.Server(), &jobsHandler{b})
+	proto.RegisterNotifyHandler(service.Server(), &notifyHandler{b})
 
 	if err := b.start(); err != nil {
 		log.Println("error starting bot", err)
@@ -510,6 +1010,15 @@ func Run(ctx *cli.Context) {
 //--discord_token			Discord token						(conf.Chat.Discord.Token)
 //--discord_whitelist			Discord Whitelist (seperated by ,)			(conf.Chat.Discord.WhiteList[])
 //--discord_prefix "Micro "		Discord Prefix						(conf.Chat.Discord.Prefix)
+//--aliases				Command aliases						(conf.Extensions["aliases"]{})
+//--aliases_file			Where runtime aliases are saved				(conf.Extensions["aliasesFile"])
+//--admins				Bot admin user IDs					(conf.Extensions["admins"][])
+//--quiet_channels			Channels without suggestions				(conf.Extensions["quietChannels"][])
+//--notifiers				Services allowed to use Notify				(conf.Extensions["notifiers"][])
+//--notify_channels			Channel aliases for Notify				(conf.Extensions["notifyChannels"]{})
+//--service_tokens			Tokens of services using Notify and Jobs		(conf.Extensions["serviceTokens"]{})
+//--discord_slash_commands		Offer commands as slash commands			(conf.Extensions["discordSlashCommands"])
+//--discord_privileged_intents		Receive member and presence events			(conf.Extensions["discordPrivilegedIntents"])
 //--help, -h				show help						(no equivalent)
 func cliContextFromConfiguration(conf *config.Configuration) *cli.Context {
 	arguments := []string{}
@@ -557,6 +1066,58 @@ func cliContextFromConfiguration(conf *config.Configuration) *cli.Context {
 	if len(conf.Chat.Discord.Prefix) > 0 {
 		arguments = append(arguments, "--discord_prefix="+conf.Chat.Discord.Prefix)
 	}
+	if aliases := extensionMap(conf, "aliases"); len(aliases) > 0 {
+		spec, err := json.Marshal(aliases)
+		if err != nil {
+			log.Println("[bot] error reading aliases", err)
+		} else {
+			arguments = append(arguments, "--aliases="+string(spec))
+		}
+	}
+	if file, ok := extension(conf, "aliasesfile").(string); ok && len(file) > 0 {
+		arguments = append(arguments, "--aliases_file="+file)
+	}
+	if admins := extensionList(conf, "admins"); len(admins) > 0 {
+		arguments = append(arguments, "--admins="+strings.Join(admins, ","))
+	}
+	if quiet := extensionList(conf, "quietchannels"); len(quiet) > 0 {
+		arguments = append(arguments, "--quiet_channels="+strings.Join(quiet, ","))
+	}
+	if notifiers := extensionList(conf, "notifiers"); len(notifiers) > 0 {
+		arguments = append(arguments, "--notifiers="+strings.Join(notifiers, ","))
+	}
+	if channels := extensionMap(conf, "notifychannels"); len(channels) > 0 {
+		var names []string
+		for name := range channels {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var spec []string
+		for _, name := range names {
+			spec = append(spec, name+"="+channels[name])
+		}
+		arguments = append(arguments, "--notify_channels="+strings.Join(spec, ","))
+	}
+	if tokens := extensionMap(conf, "servicetokens"); len(tokens) > 0 {
+		var names []string
+		for name := range tokens {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var spec []string
+		for _, name := range names {
+			spec = append(spec, name+"="+tokens[name])
+		}
+		arguments = append(arguments, "--service_tokens="+strings.Join(spec, ","))
+	}
+	if slash, ok := extension(conf, "discordslashcommands").(bool); ok && slash {
+		arguments = append(arguments, "--discord_slash_commands")
+	}
+	if privileged, ok := extension(conf, "discordprivilegedintents").(bool); ok && privileged {
+		arguments = append(arguments, "--discord_privileged_intents")
+	}
 
 	set := flagSet("config_set", App.Flags)
 	set.SetOutput(ioutil.Discard)
@@ -583,3 +1144,44 @@ func cliContextFromConfiguration(conf *config.Configuration) *cli.Context {
 
 	return ctx
 }
+
+// extension returns the value of key in the extensions section of the configuration
+func extension(conf *config.Configuration, key string) interface{} {
+	for k, v := range conf.Extensions {
+		if fmt.Sprint(k) == key {
+			return v
+		}
+	}
+	return nil
+}
+
+// extensionMap returns the extension key as a map of strings
+func extensionMap(conf *config.Configuration, key string) map[string]string {
+	m := make(map[string]string)
+
+	switch v := extension(conf, key).(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			m[k] = fmt.Sprint(val)
+		}
+	case map[interface{}]interface{}:
+		for k, val := range v {
+			m[fmt.Sprint(k)] = fmt.Sprint(val)
+		}
+	}
+
+	return m
+}
+
+// extensionList returns the extension key as a list of strings
+func extensionList(conf *config.Configuration, key string) []string {
+	var l []string
+
+	if v, ok := extension(conf, key).([]interface{}); ok {
+		for _, val := range v {
+			l = append(l, fmt.Sprint(val))
+		}
+	}
+
+	return l
+}
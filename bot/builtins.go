@@ -0,0 +1,156 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/micro/go-bot/input"
+)
+
+// notAdminMessage is sent when someone who isn't a bot admin runs an admin
+// only command
+var notAdminMessage = "You need to be a bot admin to do that."
+
+// botCommand is a command implemented by the bot itself. Unlike commands
+// registered with RegisterCommand it gets the event so it knows who sent it.
+type botCommand struct {
+	usage       string
+	description string
+	// immediate commands skip the channel queue so they can run while
+	// other commands in the channel are still running
+	immediate bool
+	exec      func(ev input.Event, args []string) ([]byte, error)
+}
+
+// botCommands returns the commands implemented by the bot keyed by name
+func (b *bot) botCommands() map[string]*botCommand {
+	return map[string]*botCommand{
+		"alias": {
+			usage:       "alias [list | add <name> <command> [args...] | remove <name>]",
+			description: "Lists and manages command aliases",
+			exec:        b.alias,
+		},
+		"suggestions": {
+			usage:       "suggestions [on | off]",
+			description: "Shows or changes whether unknown commands get suggestions in this channel",
+			exec:        b.suggestions,
+		},
+		"cancel": {
+			usage:       "cancel [id...]",
+			description: "Cancels your running commands, or the ones with the given ids",
+			immediate:   true,
+			exec:        b.cancel,
+		},
+		"running": {
+			usage:       "running",
+			description: "Lists your running commands, admins see everyone's",
+			immediate:   true,
+			exec:        b.running,
+		},
+		"jobs": {
+			usage:       "jobs",
+			description: "Lists your background jobs, admins see everyone's",
+			immediate:   true,
+			exec:        b.listJobs,
+		},
+		"job": {
+			usage:       "job <id>",
+			description: "Shows the state of a background job",
+			immediate:   true,
+			exec:        b.showJob,
+		},
+		"services": {
+			usage:       "services",
+			description: "Lists the command services with their protocol version and capabilities (admins only)",
+			immediate:   true,
+			exec:        b.listServices,
+		},
+	}
+}
+
+// botCommandHelp returns the usage lines of the bot commands
+func (b *bot) botCommandHelp() []string {
+	var names []string
+	for name := range b.internal {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var lines []string
+	for _, name := range names {
+		cmd := b.internal[name]
+		lines = append(lines, fmt.Sprintf("%s - %s", cmd.usage, cmd.description))
+	}
+
+	return lines
+}
+
+// isAdmin reports whether the sender of an event is a bot admin
+func (b *bot) isAdmin(from string) bool {
+	return b.admins[userOf(from)]
+}
+
+// isImmediate reports whether ev is a bot command that skips the queue
+func (b *bot) isImmediate(ev input.Event) bool {
+	steps, err := parseChain(string(ev.Data))
+	if err != nil || len(steps) != 1 {
+		return false
+	}
+
+	args, _ := b.aliases.expand(steps[0].args)
+	cmd, ok := b.internal[args[0]]
+	return ok && cmd.immediate
+}
+
+// reserved reports whether name is taken by a command of the bot itself and
+// can't be an alias
+func (b *bot) reserved(name string) bool {
+	_, ok := b.internal[name]
+	return ok || name == "help"
+}
+
+func (b *bot) alias(ev input.Event, args []string) ([]byte, error) {
+	if len(args) == 1 || args[1] == "list" {
+		lines := b.aliases.help()
+		if len(lines) == 0 {
+			return []byte("No aliases defined"), nil
+		}
+		return []byte(strings.Join(lines, "\n")), nil
+	}
+
+	if !b.isAdmin(ev.From) {
+		return []byte(notAdminMessage), nil
+	}
+
+	switch args[1] {
+	case "add":
+		if len(args) < 4 {
+			return nil, fmt.Errorf("usage: alias add <name> <command> [args...]")
+		}
+		if b.reserved(args[2]) {
+			return nil, fmt.Errorf("%s is a bot command and can't be aliased", args[2])
+		}
+		if err := b.aliases.set(args[2], args[3:]); err != nil {
+			log.Printf("[bot] error saving aliases: %v\n", err)
+			return nil, fmt.Errorf("%s is an alias for %s until the bot restarts, it couldn't be saved", args[2], strings.Join(args[3:], " "))
+		}
+		return []byte(fmt.Sprintf("%s is now an alias for %s", args[2], strings.Join(args[3:], " "))), nil
+	case "remove":
+		if len(args) != 3 {
+			return nil, fmt.Errorf("usage: alias remove <name>")
+		}
+		removed, err := b.aliases.remove(args[2])
+		if !removed {
+			return nil, fmt.Errorf("no alias named %s", args[2])
+		}
+		if err != nil {
+			log.Printf("[bot] error saving aliases: %v\n", err)
+			return nil, fmt.Errorf("removed alias %s until the bot restarts, it couldn't be saved", args[2])
+		}
+		return []byte(fmt.Sprintf("Removed alias %s", args[2])), nil
+	}
+
+	return nil, fmt.Errorf("not a valid subcommand: %s", args[1])
+}
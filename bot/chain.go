@@ -0,0 +1,72 @@
+package bot
+
+import (
+	"fmt"
+)
+
+// DefaultMaxChain is the most commands allowed in a chain when it isn't
+// configured
+var DefaultMaxChain = 5
+
+// step is a single command of a chain
+type step struct {
+	args []string
+	// text is the unparsed text of the command
+	text string
+	// pipe is set when the output of the previous command is passed in
+	pipe bool
+	// piped is set when the output is passed to the next command
+	piped bool
+}
+
+// parseChain splits text into the commands of a chain. Commands separated by
+// a ; run one after the other, a command following a | also gets the output
+// of the command before it. Operators need whitespace around them so they can
+// still be used in arguments.
+func parseChain(text string) ([]step, error) {
+	tokens, err := lex(text, true)
+	if err != nil {
+		return nil, err
+	}
+
+	runes := []rune(text)
+
+	var (
+		steps []step
+		cur   step
+		start int
+		end   int
+		last  string
+	)
+
+	for _, t := range tokens {
+		if !t.op {
+			if len(cur.args) == 0 {
+				start = t.start
+			}
+			cur.args = append(cur.args, t.text)
+			end = t.end
+			continue
+		}
+
+		if len(cur.args) == 0 {
+			return nil, fmt.Errorf("missing command before %s", t.text)
+		}
+
+		cur.text = string(runes[start:end])
+		cur.piped = t.text == string(opPipe)
+		steps = append(steps, cur)
+		cur = step{pipe: cur.piped}
+		last = t.text
+	}
+
+	if len(cur.args) == 0 {
+		if len(steps) > 0 {
+			return nil, fmt.Errorf("missing command after %s", last)
+		}
+		return nil, nil
+	}
+
+	cur.text = string(runes[start:end])
+	return append(steps, cur), nil
+}
@@ -0,0 +1,72 @@
+package bot
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/micro/go-bot/command"
+	"github.com/micro/go-bot/input"
+)
+
+func TestParseChain(t *testing.T) {
+	tests := []struct {
+		text  string
+		steps [][]string
+		err   bool
+	}{
+		{text: "role list", steps: [][]string{{"role", "list"}}},
+		{text: "role list ; sig list", steps: [][]string{{"role", "list"}, {"sig", "list"}}},
+		{text: "role list | grep foo", steps: [][]string{{"role", "list"}, {"grep", "foo"}}},
+		{text: "echo a;b|c", steps: [][]string{{"echo", "a;b|c"}}},
+		{text: "echo ';' | x", steps: [][]string{{"echo", ";"}, {"x"}}},
+		{text: "; role list", err: true},
+		{text: "role list ;", err: true},
+	}
+
+	for _, tt := range tests {
+		steps, err := parseChain(tt.text)
+		if (err != nil) != tt.err {
+			t.Errorf("parseChain(%q) error = %v, want error %v", tt.text, err, tt.err)
+			continue
+		}
+
+		var args [][]string
+		for _, s := range steps {
+			args = append(args, s.args)
+		}
+		if !reflect.DeepEqual(args, tt.steps) {
+			t.Errorf("parseChain(%q) = %q, want %q", tt.text, args, tt.steps)
+		}
+	}
+}
+
+func TestPipeIntoBotCommand(t *testing.T) {
+	echo := func(args ...string) ([]byte, error) {
+		return []byte(strings.Join(args, " ")), nil
+	}
+
+	b := &bot{
+		aliases: &aliasTable{},
+		internal: map[string]*botCommand{
+			"running": {exec: func(ev input.Event, args []string) ([]byte, error) { return echo(args...) }},
+		},
+		commands: newCommandTable(map[string]command.Command{
+			"^hello": command.NewCommand("hello", "hello", "", echo),
+		}),
+	}
+
+	for _, name := range []string{"running", "hello"} {
+		s := step{args: []string{name}, text: name}
+
+		out, _, err := b.execute(name, nil, input.Event{}, s, nil)
+		if err != nil || string(out) != name {
+			t.Errorf("%s: execute() = %q, %v, want %q", name, out, err, name)
+		}
+
+		s.pipe = true
+		if _, _, err := b.execute(name, nil, input.Event{}, s, []byte("output")); err == nil {
+			t.Errorf("%s: piping into a bot command didn't fail", name)
+		}
+	}
+}
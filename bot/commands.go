@@ -0,0 +1,180 @@
+package bot
+
+import (
+	"log"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strings"
+
+	"github.com/micro/go-bot/command"
+)
+
+// helpPattern is the pattern the generated help command is registered under
+const helpPattern = `^help(\s|$)`
+
+// priorities of built in commands keyed by pattern, commands without an
+// entry have a priority of 0
+var priorities = map[string]int{}
+
+// RegisterCommand registers a built in command for the given pattern. When
+// more than one pattern matches a message the command with the highest
+// priority runs, ties are broken by the pattern itself.
+func RegisterCommand(pattern string, priority int, cmd command.Command) {
+	command.Commands[pattern] = cmd
+	priorities[pattern] = priority
+}
+
+// builtin is a built in command with its pattern compiled
+type builtin struct {
+	pattern  string
+	priority int
+	re       *regexp.Regexp
+	cmd      command.Command
+}
+
+// commandTable is an ordered list of built in commands. It is never modified
+// in place so it is safe to use a copy without holding the bot lock.
+type commandTable []*builtin
+
+// newCommandTable compiles the patterns of commands and orders them by
+// priority. Commands with invalid patterns are logged and dropped.
+func newCommandTable(commands map[string]command.Command) commandTable {
+	var t commandTable
+
+	for pattern, cmd := range commands {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			log.Printf("[bot] command %s has an invalid pattern %s: %v\n", cmd.String(), pattern, err)
+			continue
+		}
+
+		t = append(t, &builtin{
+			pattern:  pattern,
+			priority: priorities[pattern],
+			re:       re,
+			cmd:      cmd,
+		})
+	}
+
+	sort.Sort(byPriority{t})
+	return t
+}
+
+// with returns a copy of the table with the command for pattern set to cmd,
+// keeping its position if the pattern is already registered.
+func (t commandTable) with(pattern string, cmd command.Command) commandTable {
+	n := make(commandTable, len(t))
+	copy(n, t)
+
+	for i, b := range n {
+		if b.pattern == pattern {
+			c := *b
+			c.cmd = cmd
+			n[i] = &c
+			return n
+		}
+	}
+
+	n = append(n, &builtin{
+		pattern:  pattern,
+		priority: priorities[pattern],
+		re:       regexp.MustCompile(pattern),
+		cmd:      cmd,
+	})
+	sort.Sort(byPriority{n})
+
+	return n
+}
+
+// without returns a copy of the table without the command for pattern
+func (t commandTable) without(pattern string) commandTable {
+	var n commandTable
+	for _, b := range t {
+		if b.pattern != pattern {
+			n = append(n, b)
+		}
+	}
+	return n
+}
+
+// match returns the first command whose pattern matches text
+func (t commandTable) match(text []byte) (command.Command, bool) {
+	for _, b := range t {
+		if b.re.Match(text) {
+			return b.cmd, true
+		}
+	}
+	return nil, false
+}
+
+// commands returns the commands in the table
+func (t commandTable) commands() []command.Command {
+	var cmds []command.Command
+	for _, b := range t {
+		cmds = append(cmds, b.cmd)
+	}
+	return cmds
+}
+
+// warnOverlaps logs every pair of patterns where a message matching one of
+// them would also match the other, along with which of them wins.
+func (t commandTable) warnOverlaps() {
+	samples := make([]string, len(t))
+	for i, b := range t {
+		samples[i] = sample(b.pattern)
+	}
+
+	for i, a := range t {
+		for j := i + 1; j < len(t); j++ {
+			b := t[j]
+			if !b.re.MatchString(samples[i]) && !a.re.MatchString(samples[j]) {
+				continue
+			}
+
+			reason := "priority"
+			if a.priority == b.priority {
+				reason = "pattern order"
+			}
+			log.Printf("[bot] patterns %s (%s) and %s (%s) overlap, %s wins by %s\n",
+				a.pattern, a.cmd.String(), b.pattern, b.cmd.String(), a.pattern, reason)
+		}
+	}
+}
+
+// sample builds a short string matched by pattern
+func sample(pattern string) string {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	writeSample(&sb, re.Simplify())
+	return sb.String()
+}
+
+func writeSample(sb *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		sb.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		if len(re.Rune) > 0 {
+			sb.WriteRune(re.Rune[0])
+		}
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		sb.WriteRune('a')
+	case syntax.OpCapture, syntax.OpPlus:
+		writeSample(sb, re.Sub[0])
+	case syntax.OpRepeat:
+		for i := 0; i < re.Min; i++ {
+			writeSample(sb, re.Sub[0])
+		}
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			writeSample(sb, sub)
+		}
+	case syntax.OpAlternate:
+		writeSample(sb, re.Sub[0])
+	}
+}
@@ -0,0 +1,90 @@
+package bot
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/micro/go-bot/command"
+)
+
+func testCommand(name string) command.Command {
+	return command.NewCommand(name, name, "", func(args ...string) ([]byte, error) {
+		return []byte(name), nil
+	})
+}
+
+// withPriorities sets the priorities of patterns until the test ends
+func withPriorities(t *testing.T, p map[string]int) {
+	old := priorities
+	priorities = p
+	t.Cleanup(func() { priorities = old })
+}
+
+func TestCommandTablePriority(t *testing.T) {
+	withPriorities(t, map[string]int{"^role list": 10})
+
+	table := newCommandTable(map[string]command.Command{
+		"^role":      testCommand("role"),
+		"^role list": testCommand("list"),
+		"^sig":       testCommand("sig"),
+	})
+
+	tests := map[string]string{
+		"role list": "list",
+		"role add":  "role",
+		"sig":       "sig",
+	}
+	for text, want := range tests {
+		cmd, ok := table.match([]byte(text))
+		if !ok || cmd.String() != want {
+			t.Errorf("match(%q) = %v, want %s", text, cmd, want)
+		}
+	}
+
+	if _, ok := table.match([]byte("unknown")); ok {
+		t.Error("match() matched an unknown command")
+	}
+
+	// replacing a command keeps its priority
+	table = table.with("^role list", testCommand("list2"))
+	if cmd, _ := table.match([]byte("role list")); cmd.String() != "list2" {
+		t.Errorf("match() after with() = %s, want list2", cmd.String())
+	}
+
+	table = table.without("^role list")
+	if cmd, _ := table.match([]byte("role list")); cmd.String() != "role" {
+		t.Errorf("match() after without() = %s, want role", cmd.String())
+	}
+}
+
+func TestWarnOverlaps(t *testing.T) {
+	withPriorities(t, map[string]int{"^role list": 10})
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	newCommandTable(map[string]command.Command{
+		"^role":      testCommand("role"),
+		"^role list": testCommand("list"),
+		"^sig":       testCommand("sig"),
+		"^sigs?$":    testCommand("sigs"),
+	}).warnOverlaps()
+
+	out := buf.String()
+	for _, want := range []string{
+		"patterns ^role list (list) and ^role (role) overlap, ^role list wins by priority",
+		"patterns ^sig (sig) and ^sigs?$ (sigs) overlap, ^sig wins by pattern order",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("warnOverlaps() logged %q, want %q", out, want)
+		}
+	}
+
+	if n := strings.Count(out, "overlap"); n != 2 {
+		t.Errorf("warnOverlaps() logged %d overlaps, want 2", n)
+	}
+}
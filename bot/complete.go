@@ -0,0 +1,179 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/micro/go-bot/input"
+	"golang.org/x/net/context"
+
+	"github.com/chremoas/chremoas/cmdline"
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+// CompleteTimeout is how long a service gets to suggest values, discord
+// only waits 3 seconds for autocomplete results
+var CompleteTimeout = 2 * time.Second
+
+// completeSuffix ends a command to ask for suggestions for the argument in
+// its place, it has to be an argument of its own like in !role add ?
+const completeSuffix = "?"
+
+// completionInput is implemented by inputs that can show suggestions
+// natively, like discord's slash command autocomplete. complete returns
+// suggestions for the value of argument in the command text, prefix is what
+// was typed of the value so far.
+type completionInput interface {
+	HandleCompletions(complete func(ev input.Event, text, argument, prefix string) ([]*proto.Suggestion, error))
+}
+
+// complete asks the service of the command in text for suggestions. No
+// suggestions are returned for commands of services that don't implement
+// Complete.
+func (b *bot) complete(name string, ev input.Event, text, argument, prefix string) ([]*proto.Suggestion, error) {
+	args, err := tokenize(text)
+	if err != nil || len(args) == 0 {
+		return nil, err
+	}
+
+	if expanded, ok := b.aliases.expand(args); ok {
+		args = expanded
+	}
+
+	return b.completeArgs(name, ev, args, text, argument, prefix)
+}
+
+func (b *bot) completeArgs(name string, ev input.Event, args []string, text, argument, prefix string) ([]*proto.Suggestion, error) {
+	service := Namespace + "." + args[0]
+
+	b.RLock()
+	info, ok := b.services[service]
+	b.RUnlock()
+
+	if !ok || !info.supports(proto.CapabilityComplete) {
+		return nil, nil
+	}
+
+	exec := &proto.ExecRequest{
+		Sender: ev.From,
+		Args:   args,
+		Text:   text,
+	}
+	setInvocation(exec, name, ev)
+
+	ctx, cancel := context.WithTimeout(context.Background(), CompleteTimeout)
+	defer cancel()
+
+	req := b.service.Client().NewRequest(service, "Completion.Complete", &proto.CompleteRequest{
+		Request:  exec,
+		Argument: argument,
+		Prefix:   prefix,
+	})
+	rsp := &proto.CompleteResponse{}
+
+	if err := b.service.Client().Call(ctx, req, rsp); err != nil {
+		return nil, err
+	}
+
+	return rsp.Suggestions, nil
+}
+
+// completeText answers a command ending in a ? of its own with suggestions
+// for the argument in its place. A quoted or escaped ?, or one at the end of
+// a word like in what?, is an ordinary argument. It reports false if the
+// command isn't asking for suggestions or its service can't make any.
+func (b *bot) completeText(name string, ev input.Event, s step) ([]byte, bool) {
+	if len(s.args) < 2 || s.args[len(s.args)-1] != completeSuffix {
+		return nil, false
+	}
+
+	raw := strings.TrimRightFunc(s.text, unicode.IsSpace)
+	before := []rune(strings.TrimSuffix(raw, completeSuffix))
+	if len(before) == len([]rune(raw)) || !unicode.IsSpace(before[len(before)-1]) {
+		return nil, false
+	}
+
+	args := append([]string{}, s.args[:len(s.args)-1]...)
+
+	if expanded, ok := b.aliases.expand(args); ok {
+		args = expanded
+	}
+
+	b.RLock()
+	info, ok := b.services[Namespace+"."+args[0]]
+	b.RUnlock()
+
+	if !ok || !info.supports(proto.CapabilityComplete) {
+		return nil, false
+	}
+
+	argument := argumentAt(info.spec, append(args, ""))
+
+	suggestions, err := b.completeArgs(name, ev, args, strings.Join(args, " "), argument, "")
+	if err != nil {
+		return []byte("error completing cmd: " + err.Error()), true
+	}
+
+	if len(suggestions) == 0 {
+		return []byte("No suggestions"), true
+	}
+
+	lines := []string{"Suggestions:"}
+	for _, sg := range suggestions {
+		if len(sg.Label) > 0 && sg.Label != sg.Value {
+			lines = append(lines, fmt.Sprintf("%s - %s", sg.Value, sg.Label))
+			continue
+		}
+		lines = append(lines, sg.Value)
+	}
+
+	return []byte(strings.Join(lines, "\n")), true
+}
+
+// argumentAt returns the name of the argument or flag the last of args is a
+// value for, or an empty string if it isn't known
+func argumentAt(spec *proto.CommandSpec, args []string) string {
+	sub, n := findSpec(spec, args[:len(args)-1])
+	schema := sub.Schema
+	if schema == nil {
+		return ""
+	}
+
+	rest := args[n : len(args)-1]
+	positional := 0
+
+	for i := 0; i < len(rest); i++ {
+		if rest[i] == "--" {
+			positional += len(rest) - i - 1
+			break
+		}
+
+		if !cmdline.IsFlag(rest[i]) {
+			positional++
+			continue
+		}
+
+		name, _, hasValue := cmdline.SplitFlag(rest[i])
+		f := findFlag(schema, name)
+		if f == nil || f.Type == proto.ArgumentType_BOOL || hasValue {
+			continue
+		}
+
+		if i+1 == len(rest) {
+			return f.Name
+		}
+		i++
+	}
+
+	if positional < len(schema.Arguments) {
+		return schema.Arguments[positional].Name
+	}
+
+	if l := len(schema.Arguments); l > 0 && schema.Arguments[l-1].Variadic {
+		return schema.Arguments[l-1].Name
+	}
+
+	return ""
+}
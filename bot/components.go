@@ -0,0 +1,233 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	protobuf "github.com/golang/protobuf/proto"
+	"github.com/micro/go-bot/input"
+	"golang.org/x/net/context"
+
+	"github.com/chremoas/chremoas/events"
+	proto "github.com/chremoas/chremoas/proto"
+	"github.com/chremoas/chremoas/render"
+)
+
+// ComponentTTL is how long components of a response can be used for when
+// the service doesn't say
+var ComponentTTL = 15 * time.Minute
+
+// expiredMessage answers components that can't be used anymore
+var expiredMessage = "That has expired, run the command again."
+
+// componentRenderer is implemented by connections that show the components
+// of rich responses themselves, the bot asks users of other connections to
+// reply with a number
+type componentRenderer interface {
+	RendersComponents() bool
+}
+
+func rendersComponents(c input.Conn) bool {
+	cr, ok := unwrap(c).(componentRenderer)
+	return ok && cr.RendersComponents()
+}
+
+// component is a component of a response that can still be used, id is the
+// one the service gave it
+type component struct {
+	id      string
+	service string
+	expires time.Time
+}
+
+// prompt is the numbered choices last offered to a user in a channel, only
+// they can answer it
+type prompt struct {
+	choices []render.Choice
+	expires time.Time
+}
+
+// componentRegistry keeps track of the components of responses. Components
+// get ids of the bot's own so services don't need to keep theirs apart.
+type componentRegistry struct {
+	sync.Mutex
+	prefix     string
+	next       int
+	components map[string]*component
+	prompts    map[string]*prompt
+}
+
+func newComponentRegistry() *componentRegistry {
+	return &componentRegistry{
+		prefix:     strconv.FormatInt(time.Now().Unix(), 36),
+		components: make(map[string]*component),
+		prompts:    make(map[string]*prompt),
+	}
+}
+
+func promptKey(name, from string) string {
+	return name + "/" + from
+}
+
+// add registers the components of a response service sent to ev on the named
+// input. It returns a copy of rich with the ids the bot gave the components.
+func (r *componentRegistry) add(service, name string, ev input.Event, rich *proto.RichResponse) *proto.RichResponse {
+	if rich == nil || len(rich.Components) == 0 {
+		return rich
+	}
+
+	rich = protobuf.Clone(rich).(*proto.RichResponse)
+
+	r.Lock()
+	defer r.Unlock()
+
+	r.prune()
+
+	var latest time.Time
+	for _, c := range rich.Components {
+		ttl := ComponentTTL
+		if c.ExpiresIn > 0 {
+			ttl = time.Duration(c.ExpiresIn) * time.Second
+		}
+
+		r.next++
+		id := fmt.Sprintf("%s-%d", r.prefix, r.next)
+		r.components[id] = &component{
+			id:      c.Id,
+			service: service,
+			expires: time.Now().Add(ttl),
+		}
+		c.Id = id
+
+		if r.components[id].expires.After(latest) {
+			latest = r.components[id].expires
+		}
+	}
+
+	r.prompts[promptKey(name, ev.From)] = &prompt{
+		choices: render.Choices(rich),
+		expires: latest,
+	}
+
+	return rich
+}
+
+// find returns the component with the id the bot gave it
+func (r *componentRegistry) find(id string) (component, bool) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.prune()
+
+	c, ok := r.components[id]
+	if !ok {
+		return component{}, false
+	}
+	return *c, true
+}
+
+// choose returns choice n, counting from 1, of the last prompt offered to
+// from and drops the prompt
+func (r *componentRegistry) choose(name, from string, n int) (render.Choice, bool) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.prune()
+
+	key := promptKey(name, from)
+	p, ok := r.prompts[key]
+	if !ok || n < 1 || n > len(p.choices) {
+		return render.Choice{}, false
+	}
+	delete(r.prompts, key)
+
+	return p.choices[n-1], true
+}
+
+// prune drops expired components and prompts, callers must hold the lock.
+func (r *componentRegistry) prune() {
+	now := time.Now()
+	for id, c := range r.components {
+		if now.After(c.expires) {
+			delete(r.components, id)
+		}
+	}
+	for k, p := range r.prompts {
+		if now.After(p.expires) {
+			delete(r.prompts, k)
+		}
+	}
+}
+
+// chosen returns what a reply picked from the last prompt offered to its
+// sender, replies are only numbers on inputs that can't show components
+func (b *bot) chosen(name string, c input.Conn, ev input.Event) (render.Choice, bool) {
+	if rendersComponents(c) {
+		return render.Choice{}, false
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(string(ev.Data)))
+	if err != nil {
+		return render.Choice{}, false
+	}
+
+	return b.components.choose(name, ev.From, n)
+}
+
+// interactEvent handles a component being used on an input that shows them
+func (b *bot) interactEvent(name string, c input.Conn, ev input.Event) error {
+	req, ok := ev.Meta[events.ComponentKey].(*proto.InteractRequest)
+	if !ok {
+		return nil
+	}
+	return b.interact(name, c, ev, req.ComponentId, req.Values)
+}
+
+// interact passes the use of component id on to the service that sent it and
+// responds with what the service answers
+func (b *bot) interact(name string, c input.Conn, ev input.Event, id string, values []string) error {
+	comp, ok := b.components.find(id)
+	if !ok {
+		return reply(c, ev, []byte(expiredMessage))
+	}
+
+	b.RLock()
+	info, ok := b.services[comp.service]
+	b.RUnlock()
+
+	if !ok {
+		return reply(c, ev, []byte(fmt.Sprintf("%s is not available right now", comp.service)))
+	}
+
+	if !info.supports(proto.CapabilityInteract) {
+		return reply(c, ev, []byte(fmt.Sprintf("%s doesn't handle buttons or menus", comp.service)))
+	}
+
+	exec := &proto.ExecRequest{Sender: ev.From}
+	setInvocation(exec, name, ev)
+
+	ctx, cancel := context.WithTimeout(context.Background(), info.timeoutOr(b.timeout))
+	defer cancel()
+
+	req := b.service.Client().NewRequest(comp.service, "Interaction.Interact", &proto.InteractRequest{
+		ComponentId: comp.id,
+		Values:      values,
+		Invocation:  exec,
+	})
+	rsp := &proto.ExecResponse{}
+
+	var err error
+	if err = b.service.Client().Call(ctx, req, rsp); err == nil && len(rsp.Error) > 0 {
+		err = errors.New(rsp.Error)
+	}
+
+	if err != nil {
+		return reply(c, ev, []byte("error executing cmd: "+err.Error()))
+	}
+
+	return respond(c, ev, rsp.Result, b.components.add(comp.service, name, ev, rsp.Rich))
+}
@@ -0,0 +1,50 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/micro/go-bot/input"
+
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+func TestPromptKeying(t *testing.T) {
+	r := newComponentRegistry()
+
+	rich := &proto.RichResponse{Components: []*proto.Component{
+		{Type: proto.ComponentType_BUTTON, Id: "yes", Label: "Yes"},
+		{Type: proto.ComponentType_SELECT, Id: "pick", Options: []*proto.SelectOption{{Value: "a"}, {Value: "b", Label: "B"}}},
+	}}
+	sent := r.add("chremoas.srv.role", "slack", input.Event{From: "c1:u1"}, rich)
+
+	// only the user offered the prompt, in the same channel and input, can
+	// answer it
+	for _, other := range []struct{ name, from string }{
+		{"discord", "c1:u1"},
+		{"slack", "c2:u1"},
+		{"slack", "c1:u2"},
+	} {
+		if _, ok := r.choose(other.name, other.from, 1); ok {
+			t.Errorf("%s %s answered the prompt of another user", other.name, other.from)
+		}
+	}
+
+	if _, ok := r.choose("slack", "c1:u1", 4); ok {
+		t.Error("choose() accepted a choice out of range")
+	}
+
+	choice, ok := r.choose("slack", "c1:u1", 3)
+	if !ok || choice.ComponentID != sent.Components[1].Id || choice.Value != "b" {
+		t.Errorf("choose() = %+v, %v, want option b of %s", choice, ok, sent.Components[1].Id)
+	}
+
+	// the bot's id leads back to the service's
+	if c, ok := r.find(choice.ComponentID); !ok || c.id != "pick" || c.service != "chremoas.srv.role" {
+		t.Errorf("find() = %+v, %v", c, ok)
+	}
+
+	// a prompt is answered once
+	if _, ok := r.choose("slack", "c1:u1", 1); ok {
+		t.Error("choose() answered a prompt twice")
+	}
+}
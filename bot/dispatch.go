@@ -0,0 +1,122 @@
+package bot
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/micro/go-bot/input"
+)
+
+// dispatcher runs events for a single input connection on a bounded pool of
+// workers. Events from the same channel are handled one at a time and in the
+// order they were received so replies within a channel stay ordered, while
+// different channels proceed concurrently.
+type dispatcher struct {
+	depth   int
+	handler func(input.Event)
+	work    chan *channelQueue
+	wg      sync.WaitGroup
+
+	sync.Mutex
+	queued   int
+	channels map[string]*channelQueue
+}
+
+type channelQueue struct {
+	key    string
+	events []input.Event
+}
+
+func newDispatcher(workers, depth int, handler func(input.Event)) *dispatcher {
+	if workers < 1 {
+		workers = 1
+	}
+	if depth < 1 {
+		depth = 1
+	}
+
+	d := &dispatcher{
+		depth:    depth,
+		handler:  handler,
+		work:     make(chan *channelQueue, depth),
+		channels: make(map[string]*channelQueue),
+	}
+
+	d.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go d.worker()
+	}
+
+	return d
+}
+
+// submit queues an event for processing. It returns false without queueing
+// the event when the queue is full.
+func (d *dispatcher) submit(ev input.Event) bool {
+	key := channelOf(ev.From)
+
+	d.Lock()
+	if d.queued >= d.depth {
+		d.Unlock()
+		return false
+	}
+	d.queued++
+
+	// a worker already owns this channel, it will pick the event up
+	if q, ok := d.channels[key]; ok {
+		q.events = append(q.events, ev)
+		d.Unlock()
+		return true
+	}
+
+	q := &channelQueue{key: key, events: []input.Event{ev}}
+	d.channels[key] = q
+	d.Unlock()
+
+	// there are never more active channels than queued events so this
+	// can't block
+	d.work <- q
+	return true
+}
+
+func (d *dispatcher) worker() {
+	defer d.wg.Done()
+
+	for q := range d.work {
+		d.drain(q)
+	}
+}
+
+func (d *dispatcher) drain(q *channelQueue) {
+	for {
+		d.Lock()
+		if len(q.events) == 0 {
+			delete(d.channels, q.key)
+			d.Unlock()
+			return
+		}
+		ev := q.events[0]
+		q.events = q.events[1:]
+		d.queued--
+		d.Unlock()
+
+		d.handler(ev)
+	}
+}
+
+// close stops accepting work and waits for queued events to finish.
+func (d *dispatcher) close() {
+	close(d.work)
+	d.wg.Wait()
+}
+
+// channelOf extracts the channel part of a "channelID:userID" sender.
+func channelOf(from string) string {
+	return strings.SplitN(from, ":", 2)[0]
+}
+
+// userOf extracts the user part of a "channelID:userID" sender.
+func userOf(from string) string {
+	parts := strings.SplitN(from, ":", 2)
+	return parts[len(parts)-1]
+}
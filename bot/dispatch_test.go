@@ -0,0 +1,91 @@
+package bot
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/micro/go-bot/input"
+)
+
+func TestDispatcherKeepsChannelOrder(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		handled = map[string][]string{}
+	)
+
+	d := newDispatcher(4, 100, func(ev input.Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		handled[channelOf(ev.From)] = append(handled[channelOf(ev.From)], string(ev.Data))
+	})
+
+	want := map[string][]string{}
+	for i := 0; i < 20; i++ {
+		for _, channel := range []string{"c1", "c2", "c3"} {
+			data := fmt.Sprint(i)
+			if !d.submit(input.Event{From: channel + ":u", Data: []byte(data)}) {
+				t.Fatalf("submit() of event %d in %s failed", i, channel)
+			}
+			want[channel] = append(want[channel], data)
+		}
+	}
+	d.close()
+
+	if !reflect.DeepEqual(handled, want) {
+		t.Errorf("handled %v, want %v", handled, want)
+	}
+}
+
+func TestDispatcherBounds(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		running  int
+		most     int
+		started  = make(chan struct{}, 10)
+		release  = make(chan struct{})
+		channels = []string{"c1", "c2", "c3"}
+	)
+
+	d := newDispatcher(2, 4, func(ev input.Event) {
+		mu.Lock()
+		running++
+		if running > most {
+			most = running
+		}
+		mu.Unlock()
+
+		started <- struct{}{}
+		<-release
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+	})
+
+	// the two workers take an event each, the rest waits in the queue
+	for _, channel := range channels {
+		if !d.submit(input.Event{From: channel + ":u"}) {
+			t.Fatalf("submit() in %s failed", channel)
+		}
+	}
+	<-started
+	<-started
+
+	for i := 0; i < 3; i++ {
+		if !d.submit(input.Event{From: "c1:u"}) {
+			t.Fatalf("submit() %d failed before the queue was full", i)
+		}
+	}
+	if d.submit(input.Event{From: "c2:u"}) {
+		t.Error("submit() succeeded with a full queue")
+	}
+
+	close(release)
+	d.close()
+
+	if most != 2 {
+		t.Errorf("%d events ran at once, want 2", most)
+	}
+}
@@ -0,0 +1,104 @@
+package bot
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/micro/go-bot/input"
+	"github.com/micro/go-micro/registry"
+	"golang.org/x/net/context"
+
+	"github.com/chremoas/chremoas/events"
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+var (
+	// EventTimeout is how long a service gets to handle a chat event
+	EventTimeout = 10 * time.Second
+	// EventQueueDepth is how many chat events wait to be delivered before
+	// new ones are dropped
+	EventQueueDepth = 256
+)
+
+// subscriptionsKey is the registry metadata key services list the chat
+// event types they want in
+const subscriptionsKey = "bot_events"
+
+// delivery is a chat event on its way to a service
+type delivery struct {
+	service string
+	event   *proto.ChatEvent
+}
+
+// subscriptions returns the chat event types service subscribed to
+func subscriptions(r registry.Registry, service string) map[string]bool {
+	services, err := r.GetService(service)
+	if err != nil {
+		return nil
+	}
+
+	subs := make(map[string]bool)
+	add := func(list string) {
+		for _, t := range strings.Split(list, ",") {
+			if t = strings.TrimSpace(t); len(t) > 0 {
+				subs[t] = true
+			}
+		}
+	}
+
+	for _, s := range services {
+		add(s.Metadata[subscriptionsKey])
+		for _, n := range s.Nodes {
+			add(n.Metadata[subscriptionsKey])
+		}
+	}
+
+	return subs
+}
+
+// publish queues the chat event in ev for the services subscribed to it
+func (b *bot) publish(name string, ev input.Event) {
+	ce, ok := ev.Meta[events.Key].(*proto.ChatEvent)
+	if !ok {
+		return
+	}
+
+	ce.Input = name
+	if ce.Timestamp == 0 {
+		ce.Timestamp = time.Now().Unix()
+	}
+
+	b.RLock()
+	services := b.services
+	b.RUnlock()
+
+	for service, info := range services {
+		if !info.events[ce.Type] {
+			continue
+		}
+
+		select {
+		case b.deliveries <- delivery{service: service, event: ce}:
+		default:
+			log.Println("[bot][events] queue full, dropping", ce.Type, "for", service)
+		}
+	}
+}
+
+// deliver sends queued chat events to services until the bot exits
+func (b *bot) deliver() {
+	for {
+		select {
+		case <-b.exit:
+			return
+		case d := <-b.deliveries:
+			ctx, cancel := context.WithTimeout(context.Background(), EventTimeout)
+			req := b.service.Client().NewRequest(d.service, "ChatEvents.Event", d.event)
+			if err := b.service.Client().Call(ctx, req, &proto.ChatEventResponse{}); err != nil {
+				log.Println("[bot][events] error delivering", d.event.Type, "to", d.service, err)
+			}
+			cancel()
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/go-bot/input"
+	"golang.org/x/net/context"
+
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+// FollowUpTimeout is how long the bot waits for the answer to a follow up
+// when the service doesn't say
+var FollowUpTimeout = 5 * time.Minute
+
+// cancelAnswer drops a follow up instead of answering it
+const cancelAnswer = "cancel"
+
+// conversation is a follow up waiting for a user's answer, path is the
+// command and subcommands of the request that asked it
+type conversation struct {
+	service string
+	path    []string
+	session string
+	expires time.Time
+}
+
+func (conv conversation) command() string {
+	return strings.Join(conv.path, " ")
+}
+
+// conversations keeps track of follow ups keyed by input and sender, a user
+// has at most one per channel. Only messages the input passes on to the bot
+// can answer them, on most inputs that means they need the command prefix or
+// to mention the bot like commands do.
+type conversations struct {
+	sync.Mutex
+	pending map[string]*conversation
+}
+
+func newConversations() *conversations {
+	return &conversations{pending: make(map[string]*conversation)}
+}
+
+func conversationKey(name, from string) string {
+	return name + "/" + from
+}
+
+// start waits for the answer of from on the named input to f, it replaces
+// any follow up from waited on before
+func (cs *conversations) start(name, from, service string, path []string, f *proto.FollowUp) {
+	timeout := FollowUpTimeout
+	if f.Timeout > 0 {
+		timeout = time.Duration(f.Timeout) * time.Second
+	}
+
+	cs.Lock()
+	defer cs.Unlock()
+
+	cs.prune()
+	cs.pending[conversationKey(name, from)] = &conversation{
+		service: service,
+		path:    append([]string(nil), path...),
+		session: f.SessionId,
+		expires: time.Now().Add(timeout),
+	}
+}
+
+// take returns the follow up waiting for an answer from from and stops
+// waiting for it
+func (cs *conversations) take(name, from string) (conversation, bool) {
+	cs.Lock()
+	defer cs.Unlock()
+
+	cs.prune()
+
+	key := conversationKey(name, from)
+	conv, ok := cs.pending[key]
+	if !ok {
+		return conversation{}, false
+	}
+	delete(cs.pending, key)
+
+	return *conv, true
+}
+
+// prune drops follow ups nobody answered in time, callers must hold the lock.
+func (cs *conversations) prune() {
+	now := time.Now()
+	for k, conv := range cs.pending {
+		if now.After(conv.expires) {
+			delete(cs.pending, k)
+		}
+	}
+}
+
+// answer sends the message in ev back to the service that asked a follow up.
+// The arguments are the command that asked followed by the answer so services
+// can route it like any other request.
+func (b *bot) answer(name string, c input.Conn, ev input.Event, conv conversation) error {
+	text := strings.TrimSpace(string(ev.Data))
+	if text == cancelAnswer {
+		return reply(c, ev, []byte(fmt.Sprintf("Stopped waiting for an answer to %s.", conv.command())))
+	}
+
+	answer, err := tokenize(text)
+	if err != nil {
+		answer = strings.Fields(text)
+	}
+	args := append(append([]string(nil), conv.path...), answer...)
+
+	b.RLock()
+	info, ok := b.services[conv.service]
+	b.RUnlock()
+
+	if !ok {
+		return reply(c, ev, []byte(fmt.Sprintf("%s is not available right now", conv.service)))
+	}
+
+	exec := &proto.ExecRequest{
+		Sender:    ev.From,
+		Args:      args,
+		Text:      text,
+		SessionId: conv.session,
+	}
+	setInvocation(exec, name, ev)
+
+	timeout := info.timeoutOr(b.timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	id := b.inflight.add(ev.From, conv.command(), cancel)
+	defer b.inflight.remove(id)
+
+	stop := stillWorking(c, ev, info.noticeAfterOr(b.noticeAfter))
+
+	req := b.service.Client().NewRequest(conv.service, "Command.Exec", exec)
+	rsp := &proto.ExecResponse{}
+	err = b.service.Client().Call(ctx, req, rsp)
+	stop()
+
+	switch {
+	case err != nil && ctx.Err() == context.Canceled:
+		if err = b.inflight.cancelled(id); err == errCancelled {
+			return nil
+		}
+	case err != nil && ctx.Err() == context.DeadlineExceeded:
+		err = fmt.Errorf("%s (%s) didn't finish within %s, giving up", conv.command(), conv.service, timeout)
+	case err != nil:
+		err = errors.New("error executing cmd: " + err.Error())
+	case len(rsp.Error) > 0:
+		err = errors.New("error executing cmd: " + rsp.Error)
+	}
+
+	if err != nil {
+		return reply(c, ev, []byte(err.Error()))
+	}
+
+	b.followUp(name, ev, conv.service, conv.path, info, rsp)
+
+	return respond(c, ev, rsp.Result, b.components.add(conv.service, name, ev, rsp.Rich))
+}
+
+// followUp waits for the sender of ev to answer if rsp asks them something,
+// path is the command and subcommands that were run
+func (b *bot) followUp(name string, ev input.Event, service string, path []string, info *serviceInfo, rsp *proto.ExecResponse) {
+	if rsp.FollowUp == nil {
+		return
+	}
+
+	if !info.supports(proto.CapabilityFollowUp) {
+		log.Printf("[bot] %s asked a follow up without advertising %s\n", service, proto.CapabilityFollowUp)
+	}
+
+	b.conversations.start(name, ev.From, service, path, rsp.FollowUp)
+}
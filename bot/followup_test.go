@@ -0,0 +1,81 @@
+package bot
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/micro/go-bot/input"
+
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+func TestConversationRouting(t *testing.T) {
+	cs := newConversations()
+	cs.start("slack", "c1:u1", "chremoas.srv.role", []string{"role", "add"}, &proto.FollowUp{SessionId: "s1"})
+
+	// answers only come from the user asked, in the same channel and input
+	for _, other := range []struct{ name, from string }{
+		{"discord", "c1:u1"},
+		{"slack", "c2:u1"},
+		{"slack", "c1:u2"},
+	} {
+		if _, ok := cs.take(other.name, other.from); ok {
+			t.Errorf("%s %s answered the follow up of another user", other.name, other.from)
+		}
+	}
+
+	// a new follow up replaces the one before
+	cs.start("slack", "c1:u1", "chremoas.srv.sig", []string{"sig", "add"}, &proto.FollowUp{SessionId: "s2"})
+
+	conv, ok := cs.take("slack", "c1:u1")
+	if !ok || conv.service != "chremoas.srv.sig" || conv.session != "s2" || !reflect.DeepEqual(conv.path, []string{"sig", "add"}) {
+		t.Errorf("take() = %+v, %v, want the sig follow up", conv, ok)
+	}
+
+	if _, ok := cs.take("slack", "c1:u1"); ok {
+		t.Error("take() returned a follow up twice")
+	}
+}
+
+func TestConversationTimeouts(t *testing.T) {
+	defer func(timeout time.Duration) { FollowUpTimeout = timeout }(FollowUpTimeout)
+	FollowUpTimeout = -time.Second
+
+	cs := newConversations()
+
+	// the default timeout applies unless the service asks for another
+	cs.start("slack", "c1:u1", "chremoas.srv.role", []string{"role"}, &proto.FollowUp{})
+	if _, ok := cs.take("slack", "c1:u1"); ok {
+		t.Error("take() returned an expired follow up")
+	}
+
+	cs.start("slack", "c1:u1", "chremoas.srv.role", []string{"role"}, &proto.FollowUp{Timeout: 60})
+	if _, ok := cs.take("slack", "c1:u1"); !ok {
+		t.Error("take() didn't return a follow up with a timeout of its own")
+	}
+}
+
+func TestAnswerWithoutService(t *testing.T) {
+	c := &sentConn{}
+	b := &bot{}
+	conv := conversation{service: "chremoas.srv.role", path: []string{"role", "add"}}
+
+	if err := b.answer("slack", c, input.Event{From: "c1:u1", Data: []byte("cancel")}, conv); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.answer("slack", c, input.Event{From: "c1:u1", Data: []byte("yes")}, conv); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(c.sent) != 2 {
+		t.Fatalf("sent %d replies, want 2", len(c.sent))
+	}
+	if !strings.Contains(string(c.sent[0].Data), "Stopped waiting") {
+		t.Errorf("cancel answer got %q", c.sent[0].Data)
+	}
+	if !strings.Contains(string(c.sent[1].Data), "not available") {
+		t.Errorf("answer without the service got %q", c.sent[1].Data)
+	}
+}
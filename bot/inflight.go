@@ -0,0 +1,176 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/go-bot/input"
+	"golang.org/x/net/context"
+)
+
+// inflight tracks the service commands that are running so they can be
+// listed and cancelled.
+type inflight struct {
+	sync.Mutex
+	next     int
+	requests map[int]*request
+}
+
+// request is a running service command
+type request struct {
+	id      int
+	user    string
+	channel string
+	command string
+	started time.Time
+	cancel  context.CancelFunc
+
+	// who cancelled the command, set with the lock held
+	cancelledBy string
+	canceller   string
+}
+
+func newInflight() *inflight {
+	return &inflight{requests: make(map[int]*request)}
+}
+
+// add starts tracking a command sent by from, cancel aborts it
+func (f *inflight) add(from, command string, cancel context.CancelFunc) int {
+	f.Lock()
+	defer f.Unlock()
+
+	f.next++
+	f.requests[f.next] = &request{
+		id:      f.next,
+		user:    userOf(from),
+		channel: channelOf(from),
+		command: command,
+		started: time.Now(),
+		cancel:  cancel,
+	}
+
+	return f.next
+}
+
+func (f *inflight) remove(id int) {
+	f.Lock()
+	delete(f.requests, id)
+	f.Unlock()
+}
+
+// list returns the running commands of user, or all of them if user is
+// empty, oldest first.
+func (f *inflight) list(user string) []*request {
+	f.Lock()
+	defer f.Unlock()
+
+	var requests []*request
+	for _, r := range f.requests {
+		if len(user) == 0 || r.user == user {
+			requests = append(requests, r)
+		}
+	}
+
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].id < requests[j].id
+	})
+
+	return requests
+}
+
+func (f *inflight) get(id int) (*request, bool) {
+	f.Lock()
+	defer f.Unlock()
+
+	r, ok := f.requests[id]
+	return r, ok
+}
+
+// cancel aborts r for the sender of ev
+func (f *inflight) cancel(r *request, ev input.Event) {
+	f.Lock()
+	r.cancelledBy = ev.From
+	r.canceller = displayName(ev)
+	f.Unlock()
+
+	r.cancel()
+}
+
+// cancelled returns the error the cancelled command id replies with. The
+// cancel command tells the channel it was run in itself, other channels are
+// told who cancelled the command.
+func (f *inflight) cancelled(id int) error {
+	f.Lock()
+	defer f.Unlock()
+
+	r, ok := f.requests[id]
+	if !ok || len(r.cancelledBy) == 0 || channelOf(r.cancelledBy) == r.channel {
+		return errCancelled
+	}
+
+	return fmt.Errorf("cancelled by %s", r.canceller)
+}
+
+func (r *request) String() string {
+	return fmt.Sprintf("%d: %s (user %s, running %s)", r.id, r.command, r.user, time.Since(r.started).Round(time.Second))
+}
+
+func (b *bot) running(ev input.Event, args []string) ([]byte, error) {
+	user := userOf(ev.From)
+	if b.isAdmin(ev.From) {
+		user = ""
+	}
+
+	requests := b.inflight.list(user)
+	if len(requests) == 0 {
+		return []byte("No commands running"), nil
+	}
+
+	var lines []string
+	for _, r := range requests {
+		lines = append(lines, r.String())
+	}
+
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+func (b *bot) cancel(ev input.Event, args []string) ([]byte, error) {
+	var requests []*request
+
+	if len(args) == 1 {
+		requests = b.inflight.list(userOf(ev.From))
+		if len(requests) == 0 {
+			return []byte("You have no commands running"), nil
+		}
+	} else {
+		for _, arg := range args[1:] {
+			id, err := strconv.Atoi(arg)
+			if err != nil {
+				return nil, fmt.Errorf("not a command id: %s", arg)
+			}
+
+			r, ok := b.inflight.get(id)
+			if !ok {
+				return nil, fmt.Errorf("no command running with id %d", id)
+			}
+
+			if r.user != userOf(ev.From) && !b.isAdmin(ev.From) {
+				return []byte(notAdminMessage), nil
+			}
+
+			requests = append(requests, r)
+		}
+	}
+
+	var cancelled []string
+	for _, r := range requests {
+		b.inflight.cancel(r, ev)
+		cancelled = append(cancelled, r.command)
+	}
+
+	return []byte("Cancelled " + strings.Join(cancelled, ", ")), nil
+}
@@ -0,0 +1,70 @@
+package bot
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/micro/go-bot/input"
+	"golang.org/x/net/context"
+
+	"github.com/chremoas/chremoas/events"
+)
+
+// track adds a running command of from to b and returns its id and context
+func track(b *bot, from, command string) (int, context.Context) {
+	ctx, cancel := context.WithCancel(context.Background())
+	return b.inflight.add(from, command, cancel), ctx
+}
+
+func TestCancelOwnCommands(t *testing.T) {
+	b := &bot{inflight: newInflight(), admins: map[string]bool{}}
+
+	id, mine := track(b, "c1:u1", "role list")
+	_, theirs := track(b, "c1:u2", "sig list")
+
+	if _, err := b.cancel(input.Event{From: "c1:u1"}, []string{"cancel"}); err != nil {
+		t.Fatal(err)
+	}
+	if mine.Err() == nil {
+		t.Error("own command wasn't cancelled")
+	}
+	if theirs.Err() != nil {
+		t.Error("command of another user was cancelled")
+	}
+
+	// the cancel command already told the channel
+	if err := b.inflight.cancelled(id); err != errCancelled {
+		t.Errorf("cancelled() = %v, want errCancelled", err)
+	}
+}
+
+func TestCancelOthersCommands(t *testing.T) {
+	b := &bot{inflight: newInflight(), admins: map[string]bool{"admin": true}}
+
+	id, ctx := track(b, "c1:u1", "role list")
+	arg := strconv.Itoa(id)
+
+	rsp, err := b.cancel(input.Event{From: "c1:u2"}, []string{"cancel", arg})
+	if err != nil || string(rsp) != notAdminMessage {
+		t.Errorf("cancel by another user = %q, %v, want %q", rsp, err, notAdminMessage)
+	}
+	if ctx.Err() != nil {
+		t.Fatal("another user cancelled the command")
+	}
+
+	// the channel of the command is told who cancelled it elsewhere
+	ev := input.Event{From: "c2:admin", Meta: map[string]interface{}{events.NameKey: "Admin"}}
+	if _, err := b.cancel(ev, []string{"cancel", arg}); err != nil {
+		t.Fatal(err)
+	}
+	if ctx.Err() == nil {
+		t.Error("admin didn't cancel the command")
+	}
+	if err := b.inflight.cancelled(id); err == nil || err.Error() != "cancelled by Admin" {
+		t.Errorf("cancelled() = %v, want cancelled by Admin", err)
+	}
+
+	if _, err := b.cancel(ev, []string{"cancel", "99"}); err == nil {
+		t.Error("cancelling an unknown id succeeded")
+	}
+}
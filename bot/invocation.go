@@ -0,0 +1,54 @@
+package bot
+
+import (
+	"time"
+
+	"github.com/micro/go-bot/input"
+
+	"github.com/chremoas/chremoas/events"
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+// setInvocation fills in where the command in req came from. Inputs say so
+// in the meta under the keys of the events package, what can be found out
+// depends on the input.
+func setInvocation(req *proto.ExecRequest, name string, ev input.Event) {
+	req.Input = name
+	req.ChannelId = channelOf(ev.From)
+	req.UserId = userOf(ev.From)
+	req.Timestamp = time.Now().Unix()
+
+	meta := func(key string) string {
+		s, _ := ev.Meta[key].(string)
+		return s
+	}
+
+	req.Prefix = meta("prefix")
+	req.GuildId = meta(events.GuildKey)
+	req.DisplayName = meta(events.NameKey)
+	req.MessageId = meta(events.MessageKey)
+	req.ThreadId = meta(events.ThreadKey)
+	req.DirectMessage, _ = ev.Meta[events.DirectKey].(bool)
+
+	if channel := meta(events.ChannelKey); len(channel) > 0 {
+		req.ChannelId = channel
+	}
+	if user := meta(events.UserKey); len(user) > 0 {
+		req.UserId = user
+	}
+	if t, ok := ev.Meta[events.TimeKey].(time.Time); ok && !t.IsZero() {
+		req.Timestamp = t.Unix()
+	}
+}
+
+// displayName returns the name of the sender of ev, or their id if the input
+// doesn't say
+func displayName(ev input.Event) string {
+	req := &proto.ExecRequest{}
+	setInvocation(req, "", ev)
+
+	if len(req.DisplayName) > 0 {
+		return req.DisplayName
+	}
+	return req.UserId
+}
@@ -0,0 +1,53 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/micro/go-bot/input"
+
+	"github.com/chremoas/chremoas/events"
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+func TestSetInvocation(t *testing.T) {
+	sent := time.Unix(1500000000, 0)
+	ev := input.Event{
+		From: "c1:u1",
+		Meta: map[string]interface{}{
+			"prefix":          "!",
+			events.GuildKey:   "g1",
+			events.ChannelKey: "c2",
+			events.NameKey:    "nick",
+			events.MessageKey: "m1",
+			events.DirectKey:  true,
+			events.TimeKey:    sent,
+		},
+	}
+
+	req := &proto.ExecRequest{}
+	setInvocation(req, "discord", ev)
+
+	want := &proto.ExecRequest{
+		Input:         "discord",
+		Prefix:        "!",
+		GuildId:       "g1",
+		ChannelId:     "c2",
+		UserId:        "u1",
+		DisplayName:   "nick",
+		MessageId:     "m1",
+		DirectMessage: true,
+		Timestamp:     sent.Unix(),
+	}
+	if req.String() != want.String() {
+		t.Errorf("setInvocation() = %v, want %v", req, want)
+	}
+}
+
+func TestDisplayNameWithoutMeta(t *testing.T) {
+	// direct messages of hipchat only have the sender in From
+	ev := input.Event{From: "user@chat.hipchat.com"}
+	if name := displayName(ev); name != "user@chat.hipchat.com" {
+		t.Errorf("displayName() = %q, want the sender", name)
+	}
+}
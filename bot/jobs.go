@@ -0,0 +1,284 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/go-bot/input"
+	"golang.org/x/net/context"
+
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+var (
+	// JobRetention is how long finished jobs can still be looked up
+	JobRetention = time.Hour
+	// JobMaxAge is how long the bot waits for a job to finish before it
+	// gives up on it
+	JobMaxAge = 24 * time.Hour
+	// JobGrace is how long updates for jobs the bot doesn't know about yet
+	// are kept, a quick service can report on a job before the bot has the
+	// response that started it
+	JobGrace = time.Minute
+)
+
+// errJobPending is returned for updates kept until the bot knows their job
+var errJobPending = errors.New("job not started yet")
+
+// job is a command a service runs in the background
+type job struct {
+	id       string
+	service  string
+	command  string
+	input    string
+	ev       input.Event
+	started  time.Time
+	status   string
+	finished time.Time
+	err      string
+}
+
+// heldUpdate is an update for a job the bot doesn't know about yet
+type heldUpdate struct {
+	update   *proto.JobUpdate
+	received time.Time
+}
+
+// jobTracker keeps track of background jobs keyed by service and job id
+type jobTracker struct {
+	sync.Mutex
+	jobs map[string]*job
+	held map[string][]heldUpdate
+}
+
+func newJobTracker() *jobTracker {
+	return &jobTracker{jobs: make(map[string]*job), held: make(map[string][]heldUpdate)}
+}
+
+func jobKey(service, id string) string {
+	return service + "/" + id
+}
+
+// add starts tracking j and applies the updates its service sent before, it
+// returns the update that finished j if there was one.
+func (t *jobTracker) add(j *job) *proto.JobUpdate {
+	t.Lock()
+	defer t.Unlock()
+
+	t.prune()
+
+	key := jobKey(j.service, j.id)
+	t.jobs[key] = j
+
+	held := t.held[key]
+	delete(t.held, key)
+
+	for _, h := range held {
+		if j.apply(h.update) {
+			return h.update
+		}
+	}
+
+	return nil
+}
+
+// update applies an update from service, it returns a copy of the job as it
+// is after the update. Updates for jobs that aren't known yet are kept for
+// add and errJobPending is returned.
+func (t *jobTracker) update(service string, u *proto.JobUpdate) (job, error) {
+	t.Lock()
+	defer t.Unlock()
+
+	key := jobKey(service, u.JobId)
+	j, ok := t.jobs[key]
+	if !ok {
+		t.held[key] = append(t.held[key], heldUpdate{update: u, received: time.Now()})
+		return job{}, errJobPending
+	}
+
+	if !j.finished.IsZero() {
+		return job{}, fmt.Errorf("job %s has already finished", u.JobId)
+	}
+
+	j.apply(u)
+	return *j, nil
+}
+
+// apply applies u to j and reports whether it finished j, callers must hold
+// the lock of the tracker.
+func (j *job) apply(u *proto.JobUpdate) bool {
+	if len(u.Status) > 0 {
+		j.status = u.Status
+	}
+
+	if u.Done {
+		j.finished = time.Now()
+		j.err = u.Error
+	}
+
+	return u.Done
+}
+
+// result is what is posted when u finishes j
+func (j *job) result(u *proto.JobUpdate) []byte {
+	if len(u.Error) > 0 {
+		return []byte(fmt.Sprintf("job %s (%s) failed: %s", j.id, j.command, u.Error))
+	}
+	return u.Result
+}
+
+// find returns copies of the jobs with id, or of every job if id is empty,
+// that were started by user or by anyone if user is empty.
+func (t *jobTracker) find(id, user string) []job {
+	t.Lock()
+	defer t.Unlock()
+
+	t.prune()
+
+	var jobs []job
+	for _, j := range t.jobs {
+		if len(id) > 0 && j.id != id {
+			continue
+		}
+		if len(user) > 0 && userOf(j.ev.From) != user {
+			continue
+		}
+		jobs = append(jobs, *j)
+	}
+
+	sort.Slice(jobs, func(i, k int) bool {
+		return jobs[i].started.Before(jobs[k].started)
+	})
+
+	return jobs
+}
+
+// prune gives up on jobs running for more than JobMaxAge, drops jobs that
+// finished more than JobRetention ago and updates held for longer than
+// JobGrace, callers must hold the lock.
+func (t *jobTracker) prune() {
+	for k, held := range t.held {
+		if time.Since(held[0].received) > JobGrace {
+			log.Printf("[bot][jobs] dropping %d updates for unknown job %s\n", len(held), k)
+			delete(t.held, k)
+		}
+	}
+
+	for k, j := range t.jobs {
+		if j.finished.IsZero() && time.Since(j.started) > JobMaxAge {
+			j.finished = time.Now()
+			j.err = "no result after " + JobMaxAge.String()
+		}
+
+		if !j.finished.IsZero() && time.Since(j.finished) > JobRetention {
+			delete(t.jobs, k)
+		}
+	}
+}
+
+func (j job) String() string {
+	state := "running for " + time.Since(j.started).Round(time.Second).String()
+	switch {
+	case !j.finished.IsZero() && len(j.err) > 0:
+		state = "failed: " + j.err
+	case !j.finished.IsZero():
+		state = "finished " + time.Since(j.finished).Round(time.Second).String() + " ago"
+	case len(j.status) > 0:
+		state += ", " + j.status
+	}
+
+	return fmt.Sprintf("%s: %s (%s)", j.id, j.command, state)
+}
+
+// jobsHandler is the Jobs service the bot hosts for command services
+type jobsHandler struct {
+	bot *bot
+}
+
+func (h *jobsHandler) Update(ctx context.Context, req *proto.JobUpdate, rsp *proto.JobUpdateResponse) error {
+	service, err := h.bot.authenticate(ctx)
+	if err != nil {
+		return err
+	}
+
+	j, err := h.bot.jobs.update(service, req)
+	if err == errJobPending {
+		// posted once the bot knows the job
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !req.Done {
+		return nil
+	}
+
+	rich := h.bot.components.add(service, j.input, j.ev, req.Rich)
+	if err := h.bot.post(j.input, j.ev, j.result(req), rich); err != nil {
+		log.Printf("[bot][jobs] error posting result of %s: %v\n", jobKey(j.service, j.id), err)
+	}
+
+	return nil
+}
+
+// post sends data back to where ev came from on the current connection of
+// the named input.
+func (b *bot) post(name string, ev input.Event, data []byte, rich *proto.RichResponse) error {
+	b.RLock()
+	c, ok := b.conns[name]
+	b.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("%s is not connected", name)
+	}
+
+	return respond(c, ev, data, rich)
+}
+
+func (b *bot) listJobs(ev input.Event, args []string) ([]byte, error) {
+	user := userOf(ev.From)
+	if b.isAdmin(ev.From) {
+		user = ""
+	}
+
+	jobs := b.jobs.find("", user)
+	if len(jobs) == 0 {
+		return []byte("No jobs"), nil
+	}
+
+	var lines []string
+	for _, j := range jobs {
+		lines = append(lines, j.String())
+	}
+
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+func (b *bot) showJob(ev input.Event, args []string) ([]byte, error) {
+	if len(args) != 2 {
+		return nil, errors.New("usage: job <id>")
+	}
+
+	user := userOf(ev.From)
+	if b.isAdmin(ev.From) {
+		user = ""
+	}
+
+	jobs := b.jobs.find(args[1], user)
+	if len(jobs) == 0 {
+		return nil, fmt.Errorf("no job %s", args[1])
+	}
+
+	var lines []string
+	for _, j := range jobs {
+		lines = append(lines, j.String())
+	}
+
+	return []byte(strings.Join(lines, "\n")), nil
+}
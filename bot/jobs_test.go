@@ -0,0 +1,71 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+func TestJobUpdatedBeforeAdded(t *testing.T) {
+	jobs := newJobTracker()
+
+	// the service reports before the bot has the Exec response
+	if _, err := jobs.update("sig", &proto.JobUpdate{JobId: "1", Status: "halfway"}); err != errJobPending {
+		t.Fatalf("update() error = %v, want errJobPending", err)
+	}
+	if _, err := jobs.update("sig", &proto.JobUpdate{JobId: "1", Done: true, Result: []byte("done")}); err != errJobPending {
+		t.Fatalf("update() error = %v, want errJobPending", err)
+	}
+
+	j := &job{id: "1", service: "sig", command: "sig sync", started: time.Now()}
+	done := jobs.add(j)
+	if done == nil || string(j.result(done)) != "done" {
+		t.Fatalf("add() = %v, want the update that finished the job", done)
+	}
+	if j.status != "halfway" || j.finished.IsZero() {
+		t.Errorf("job = %+v, want it finished with the held status", j)
+	}
+
+	if _, err := jobs.update("sig", &proto.JobUpdate{JobId: "1", Done: true}); err == nil {
+		t.Errorf("update() of a finished job didn't fail")
+	}
+}
+
+func TestJobUpdatedAfterAdded(t *testing.T) {
+	jobs := newJobTracker()
+
+	if done := jobs.add(&job{id: "1", service: "sig", command: "sig sync", started: time.Now()}); done != nil {
+		t.Fatalf("add() = %v, want nil", done)
+	}
+
+	j, err := jobs.update("sig", &proto.JobUpdate{JobId: "1", Done: true, Error: "no sigs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(j.result(&proto.JobUpdate{Error: "no sigs"})); got != "job 1 (sig sync) failed: no sigs" {
+		t.Errorf("result() = %q", got)
+	}
+
+	// other services can't update the job
+	if _, err := jobs.update("role", &proto.JobUpdate{JobId: "1", Done: true}); err != errJobPending {
+		t.Errorf("update() by another service error = %v, want errJobPending", err)
+	}
+}
+
+func TestJobPrune(t *testing.T) {
+	jobs := newJobTracker()
+	jobs.jobs["sig/old"] = &job{id: "old", service: "sig", started: time.Now().Add(-JobMaxAge - time.Minute)}
+	jobs.held["sig/unknown"] = []heldUpdate{{update: &proto.JobUpdate{JobId: "unknown"}, received: time.Now().Add(-JobGrace - time.Second)}}
+
+	jobs.Lock()
+	jobs.prune()
+	jobs.Unlock()
+
+	if j := jobs.jobs["sig/old"]; j.finished.IsZero() || len(j.err) == 0 {
+		t.Errorf("job running past JobMaxAge = %+v, want it given up on", j)
+	}
+	if len(jobs.held) != 0 {
+		t.Errorf("held updates = %v, want the old ones dropped", jobs.held)
+	}
+}
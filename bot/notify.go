@@ -0,0 +1,67 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+// directMessenger is implemented by connections that can message users
+// directly
+type directMessenger interface {
+	// DirectChannel returns the channel for direct messages with user
+	DirectChannel(user string) (string, error)
+}
+
+// notifyHandler is the Notify service the bot hosts for other services
+type notifyHandler struct {
+	bot *bot
+}
+
+func (h *notifyHandler) Send(ctx context.Context, req *proto.Notification, rsp *proto.NotificationResponse) error {
+	service, err := h.bot.authenticate(ctx)
+	if err != nil {
+		return err
+	}
+	if !h.bot.notifiers[service] {
+		return fmt.Errorf("%q is not allowed to send notifications", service)
+	}
+
+	if len(req.Message) == 0 && req.Rich == nil {
+		return errors.New("empty notification")
+	}
+
+	h.bot.RLock()
+	c, ok := h.bot.conns[req.Input]
+	h.bot.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("%s is not connected", req.Input)
+	}
+
+	var channel string
+	switch {
+	case len(req.ChannelId) > 0:
+		channel = req.ChannelId
+	case len(req.Channel) > 0:
+		if channel, ok = h.bot.channels[req.Channel]; !ok {
+			return fmt.Errorf("no channel %s", req.Channel)
+		}
+	case len(req.UserId) > 0:
+		dm, ok := unwrap(c).(directMessenger)
+		if !ok {
+			return fmt.Errorf("%s can't send direct messages", req.Input)
+		}
+
+		if channel, err = dm.DirectChannel(req.UserId); err != nil {
+			return err
+		}
+	default:
+		return errors.New("no channel or user to notify")
+	}
+
+	return send(c, messageTo(channel, req.Message), req.Rich)
+}
@@ -0,0 +1,69 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/micro/go-bot/input"
+	"github.com/micro/go-micro/metadata"
+	"golang.org/x/net/context"
+
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+// sentConn records the events sent to it
+type sentConn struct {
+	input.Conn
+	sent []*input.Event
+}
+
+func (c *sentConn) Send(ev *input.Event) error {
+	c.sent = append(c.sent, ev)
+	return nil
+}
+
+func withToken(token string) context.Context {
+	return metadata.NewContext(context.Background(), metadata.Metadata{proto.TokenHeader: token})
+}
+
+func TestNotifyAuthentication(t *testing.T) {
+	c := &sentConn{}
+	b := &bot{
+		tokens:    map[string]string{"perms": "secret1", "sigs": "secret2"},
+		notifiers: map[string]bool{"perms": true},
+		channels:  map[string]string{"ops": "c9"},
+		conns:     map[string]input.Conn{"discord": c},
+	}
+	h := &notifyHandler{bot: b}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		ok   bool
+	}{
+		{name: "no token", ctx: context.Background()},
+		{name: "wrong token", ctx: withToken("secret3")},
+		{name: "not allowed", ctx: withToken("secret2")},
+		{name: "allowed", ctx: withToken("secret1"), ok: true},
+	}
+
+	for _, tt := range tests {
+		req := &proto.Notification{Input: "discord", Channel: "ops", Message: []byte("hi")}
+		err := h.Send(tt.ctx, req, &proto.NotificationResponse{})
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: Send() error = %v, want success %v", tt.name, err, tt.ok)
+		}
+	}
+
+	if len(c.sent) != 1 || c.sent[0].To != "c9:" || string(c.sent[0].Data) != "hi" {
+		t.Errorf("sent %v, want a single message to c9", c.sent)
+	}
+}
+
+func TestAuthenticateHeaderCase(t *testing.T) {
+	b := &bot{tokens: map[string]string{"perms": "secret1"}}
+
+	ctx := metadata.NewContext(context.Background(), metadata.Metadata{"bot-token": "secret1"})
+	if service, err := b.authenticate(ctx); err != nil || service != "perms" {
+		t.Errorf("authenticate() = %q, %v, want perms", service, err)
+	}
+}
@@ -0,0 +1,286 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/micro/go-bot/input"
+
+	proto "github.com/chremoas/chremoas/proto"
+	"github.com/chremoas/chremoas/render"
+)
+
+var (
+	// MessageLimits is the largest message in bytes each input accepts,
+	// longer responses are split
+	MessageLimits = map[string]int{
+		"discord": 2000,
+		"slack":   4000,
+		"hipchat": 10000,
+	}
+
+	// AttachmentName is the file name used for responses sent as attachments
+	AttachmentName = "response.txt"
+)
+
+// fileSender is implemented by connections that can send attachments
+type fileSender interface {
+	SendFile(ev *input.Event, name string, data []byte) error
+}
+
+// richSender is implemented by connections that can show rich responses
+type richSender interface {
+	SendRich(ev *input.Event, rich *proto.RichResponse) error
+}
+
+// partialError is implemented by errors of connections that failed after
+// sending part of a rich response, the rest isn't sent as text so nothing
+// is repeated
+type partialError interface {
+	Partial() bool
+}
+
+// editor is implemented by connections that can edit messages they sent
+type editor interface {
+	// SendEditable sends ev and returns the id to edit the message with
+	SendEditable(ev *input.Event) (string, error)
+	Edit(ev *input.Event, id string) error
+}
+
+// editorOf returns the editor of the input behind c if it has one
+func editorOf(c input.Conn) (editor, bool) {
+	ed, ok := unwrap(c).(editor)
+	return ed, ok
+}
+
+// unwrap returns the connection of the input behind c
+func unwrap(c input.Conn) input.Conn {
+	if oc, ok := c.(*outputConn); ok {
+		return oc.Conn
+	}
+	return c
+}
+
+// outputConn wraps a connection to adapt responses to what the input
+// supports. Responses that are too long are split into several messages, or
+// sent as an attachment once they are longer than attachAfter, and rich
+// responses are rendered as text for inputs that can't show them.
+type outputConn struct {
+	input.Conn
+	limit       int
+	attachAfter int
+}
+
+func newOutputConn(c input.Conn, name string, attachAfter int) *outputConn {
+	return &outputConn{
+		Conn:        c,
+		limit:       MessageLimits[name],
+		attachAfter: attachAfter,
+	}
+}
+
+func (s *outputConn) Send(ev *input.Event) error {
+	if s.limit == 0 || len(ev.Data) <= s.limit {
+		return s.Conn.Send(ev)
+	}
+
+	if fs, ok := s.Conn.(fileSender); ok && s.attachAfter > 0 && len(ev.Data) > s.attachAfter {
+		err := fs.SendFile(ev, AttachmentName, ev.Data)
+		if err == nil {
+			return nil
+		}
+		log.Println("[bot][send] error sending attachment, splitting instead", err)
+	}
+
+	for _, piece := range splitMessage(string(ev.Data), s.limit) {
+		e := *ev
+		e.Data = []byte(piece)
+		if err := s.Conn.Send(&e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *outputConn) SendRich(ev *input.Event, rich *proto.RichResponse) error {
+	if err := s.sendRich(ev, rich); err != nil {
+		return err
+	}
+
+	if len(rich.Components) == 0 || rendersComponents(s.Conn) {
+		return nil
+	}
+
+	// components are offered as numbered choices instead
+	e := *ev
+	e.Data = []byte(render.Prompt(render.Choices(rich)))
+	return s.Send(&e)
+}
+
+func (s *outputConn) sendRich(ev *input.Event, rich *proto.RichResponse) error {
+	rs, native := s.Conn.(richSender)
+	if native {
+		err := rs.SendRich(ev, rich)
+		if err == nil {
+			return nil
+		}
+		if p, ok := err.(partialError); ok && p.Partial() {
+			return err
+		}
+		log.Println("[bot][send] error sending rich response, sending it as text instead", err)
+	}
+
+	// result is the fallback for inputs that can't show rich responses or
+	// responses they couldn't send
+	e := *ev
+	if len(e.Data) == 0 {
+		e.Data = []byte(render.Text(rich))
+	}
+
+	// a response can be nothing but components
+	if len(e.Data) > 0 {
+		if err := s.Send(&e); err != nil {
+			return err
+		}
+	}
+
+	fs, ok := s.Conn.(fileSender)
+	for _, a := range rich.Attachments {
+		if !ok {
+			e.Data = []byte(fmt.Sprintf("(%s can't be attached here)", a.Name))
+			if err := s.Send(&e); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := fs.SendFile(ev, a.Name, a.Data); err != nil {
+			return err
+		}
+	}
+
+	// inputs that show components still get them on their own
+	if native && len(rich.Components) > 0 && rendersComponents(s.Conn) {
+		return rs.SendRich(ev, &proto.RichResponse{Components: rich.Components})
+	}
+
+	return nil
+}
+
+// maxFenceLanguage is the longest language of a code fence that is kept when
+// the fence is opened again
+const maxFenceLanguage = 20
+
+// splitMessage breaks text into pieces of at most limit bytes, preferring to
+// break at the end of a line. A code fence left open at the end of a piece is
+// closed and opened again at the start of the next one.
+func splitMessage(text string, limit int) []string {
+	const closeFence = "\n" + codeFence
+
+	var (
+		pieces []string
+		cur    strings.Builder
+		opener string // the fence to reopen with, empty outside of a fence
+		prefix int    // length of the reopened fence at the start of cur
+	)
+
+	flush := func() {
+		piece := cur.String()
+		if opener != "" {
+			piece = strings.TrimRight(piece, "\n") + closeFence
+		}
+		pieces = append(pieces, piece)
+
+		cur.Reset()
+		prefix = 0
+		if opener != "" {
+			cur.WriteString(opener + "\n")
+			prefix = cur.Len()
+		}
+	}
+
+	for _, line := range strings.SplitAfter(text, "\n") {
+		for len(line) > 0 {
+			// the fence is only closed again if it is still open after
+			// the line
+			room := limit - cur.Len()
+			if fenceState(opener, line) != "" {
+				room -= len(closeFence)
+			}
+
+			if len(line) <= room {
+				cur.WriteString(line)
+				opener = fenceState(opener, line)
+				break
+			}
+
+			// start a new piece before breaking up the line, unless the
+			// piece holds nothing but the fence it opens
+			if cur.Len() > prefix && cur.String() != opener+"\n" {
+				flush()
+				continue
+			}
+
+			// the line alone is too long, break it at a rune boundary and
+			// leave room to close the fence if it is open after the cut
+			cut := runeCut(line, limit-cur.Len())
+			if fenceState(opener, line[:cut]) != "" {
+				cut = runeCut(line, limit-len(closeFence)-cur.Len())
+			}
+
+			cur.WriteString(line[:cut])
+			opener = fenceState(opener, line[:cut])
+			line = line[cut:]
+			flush()
+		}
+	}
+
+	if cur.Len() > prefix {
+		pieces = append(pieces, cur.String())
+	}
+
+	return pieces
+}
+
+// runeCut returns where to cut text to keep at most n bytes without breaking
+// a rune. At least a rune is kept, even when n is too small for it.
+func runeCut(text string, n int) int {
+	if n > len(text) {
+		n = len(text)
+	}
+	for n > 0 && n < len(text) && !utf8.RuneStart(text[n]) {
+		n--
+	}
+	if n <= 0 {
+		_, n = utf8.DecodeRuneInString(text)
+	}
+	return n
+}
+
+// fenceState returns the fence that is open after text given the one open
+// before it.
+func fenceState(opener, text string) string {
+	for {
+		i := strings.Index(text, codeFence)
+		if i == -1 {
+			return opener
+		}
+		text = text[i+len(codeFence):]
+
+		if opener != "" {
+			opener = ""
+			continue
+		}
+
+		// keep the language of fences like ```go, anything longer than a
+		// language isn't repeated in every piece
+		opener = codeFence
+		end := strings.IndexAny(text, " \n`")
+		if end > 0 && end <= maxFenceLanguage && text[end] == '\n' {
+			opener += text[:end]
+		}
+	}
+}
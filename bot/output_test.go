@@ -0,0 +1,126 @@
+package bot
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/micro/go-bot/input"
+
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+func TestSplitMessage(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		limit  int
+		pieces []string
+	}{
+		{
+			name:   "fits",
+			text:   "hello",
+			limit:  10,
+			pieces: []string{"hello"},
+		},
+		{
+			name:   "breaks at lines",
+			text:   "aaaa\nbbbb\ncccc\n",
+			limit:  14,
+			pieces: []string{"aaaa\nbbbb\n", "cccc\n"},
+		},
+		{
+			name:   "breaks long lines",
+			text:   strings.Repeat("a", 12),
+			limit:  9,
+			pieces: []string{"aaaaaaaaa", "aaa"},
+		},
+		{
+			name:   "breaks at runes",
+			text:   "ééééé",
+			limit:  9,
+			pieces: []string{"éééé", "é"},
+		},
+		{
+			name:   "breaks long lines in fences",
+			text:   "```\n" + strings.Repeat("a", 12) + "\n```",
+			limit:  16,
+			pieces: []string{"```\naaaaaaaa\n```", "```\naaaa\n```"},
+		},
+		{
+			name:   "reopens fences",
+			text:   "```go\naaaa\nbbbb\ncccc\n```",
+			limit:  20,
+			pieces: []string{"```go\naaaa\nbbbb\n```", "```go\ncccc\n```"},
+		},
+	}
+
+	for _, tt := range tests {
+		pieces := splitMessage(tt.text, tt.limit)
+		if !reflect.DeepEqual(pieces, tt.pieces) {
+			t.Errorf("%s: splitMessage() = %q, want %q", tt.name, pieces, tt.pieces)
+		}
+	}
+}
+
+func TestSplitMessageLongFence(t *testing.T) {
+	// a fence with a long word after it isn't repeated in every piece, and
+	// a limit too small for the fences still makes progress
+	text := codeFence + strings.Repeat("x", 100) + "\n" + strings.Repeat("y", 100) + "\n" + codeFence
+
+	for _, limit := range []int{5, 30, 2000} {
+		pieces := splitMessage(text, limit)
+		if len(pieces) == 0 {
+			t.Fatalf("limit %d: no pieces", limit)
+		}
+
+		for _, p := range pieces[1:] {
+			if strings.HasPrefix(p, codeFence+"x") {
+				t.Errorf("limit %d: piece %q reopens the fence with the long word", limit, p)
+			}
+		}
+
+		for _, p := range pieces {
+			if !utf8.ValidString(p) {
+				t.Errorf("limit %d: piece %q isn't valid utf-8", limit, p)
+			}
+		}
+	}
+}
+
+// richConn counts the messages sent and fails rich responses with err
+type richConn struct {
+	input.Conn
+	err  error
+	sent int
+}
+
+func (c *richConn) Send(ev *input.Event) error {
+	c.sent++
+	return nil
+}
+
+func (c *richConn) SendRich(ev *input.Event, rich *proto.RichResponse) error {
+	return c.err
+}
+
+type partlySent struct{}
+
+func (partlySent) Error() string { return "upload failed" }
+func (partlySent) Partial() bool { return true }
+
+func TestSendRichFallback(t *testing.T) {
+	rich := &proto.RichResponse{Title: "title"}
+
+	c := &richConn{err: errors.New("post failed")}
+	if err := newOutputConn(c, "", 0).SendRich(&input.Event{}, rich); err != nil || c.sent != 1 {
+		t.Errorf("failed rich response: err %v, %d messages sent, want it sent as text", err, c.sent)
+	}
+
+	c = &richConn{err: partlySent{}}
+	if err := newOutputConn(c, "", 0).SendRich(&input.Event{}, rich); err == nil || c.sent != 0 {
+		t.Errorf("partly sent rich response: err %v, %d messages sent, want the error and nothing sent again", err, c.sent)
+	}
+}
@@ -0,0 +1,229 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chremoas/chremoas/cmdline"
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+// findSpec follows args, args[0] being the command, down the subcommands of
+// spec. It returns the deepest subcommand named and how many of args name it.
+func findSpec(spec *proto.CommandSpec, args []string) (*proto.CommandSpec, int) {
+	n := 1
+	for ; n < len(args); n++ {
+		sub := subcommand(spec, args[n])
+		if sub == nil {
+			break
+		}
+		spec = sub
+	}
+	return spec, n
+}
+
+func subcommand(spec *proto.CommandSpec, name string) *proto.CommandSpec {
+	if spec.Schema == nil {
+		return nil
+	}
+	for _, sub := range spec.Schema.Subcommands {
+		if sub.Name == name {
+			return sub
+		}
+	}
+	return nil
+}
+
+func findFlag(schema *proto.CommandSchema, name string) *proto.Flag {
+	for _, f := range schema.Flags {
+		if f.Name == name || (len(f.Short) > 0 && f.Short == name) {
+			return f
+		}
+	}
+	return nil
+}
+
+// validate checks args against the schema of spec, args[0] being the command.
+// Commands without a schema take anything.
+func validate(spec *proto.CommandSpec, args []string) error {
+	sub, n := findSpec(spec, args)
+	schema := sub.Schema
+	if schema == nil {
+		return nil
+	}
+
+	fail := func(format string, a ...interface{}) error {
+		return fmt.Errorf(format+"\nusage: %s", append(a, usageOf(strings.Join(args[:n], " "), sub))...)
+	}
+
+	var (
+		positional []string
+		seen       = make(map[string]bool)
+		rest       = args[n:]
+	)
+
+	for i := 0; i < len(rest); i++ {
+		arg := rest[i]
+
+		if arg == "--" {
+			positional = append(positional, rest[i+1:]...)
+			break
+		}
+
+		if !cmdline.IsFlag(arg) {
+			positional = append(positional, arg)
+			continue
+		}
+
+		name, value, hasValue := cmdline.SplitFlag(arg)
+		f := findFlag(schema, name)
+		if f == nil {
+			return fail("unknown flag %s", arg)
+		}
+
+		if f.Type != proto.ArgumentType_BOOL && !hasValue {
+			if i+1 == len(rest) {
+				return fail("flag %s needs a value", arg)
+			}
+			i++
+			value, hasValue = rest[i], true
+		}
+
+		if hasValue {
+			if err := cmdline.Check(f.Type, f.Choices, value); err != nil {
+				return fail("flag %s: %v", arg, err)
+			}
+		}
+		seen[f.Name] = true
+	}
+
+	for _, f := range schema.Flags {
+		if f.Required && !seen[f.Name] {
+			return fail("missing flag --%s", f.Name)
+		}
+	}
+
+	if len(schema.Subcommands) > 0 && len(schema.Arguments) == 0 && len(positional) > 0 {
+		var names []string
+		for _, s := range schema.Subcommands {
+			if !s.Hidden {
+				names = append(names, s.Name)
+			}
+		}
+		return fail("unknown subcommand %s, expected one of %s", positional[0], strings.Join(names, ", "))
+	}
+
+	variadic := false
+	for i, a := range schema.Arguments {
+		if i >= len(positional) {
+			if a.Required {
+				return fail("missing argument <%s>", a.Name)
+			}
+			continue
+		}
+
+		values := positional[i : i+1]
+		if a.Variadic {
+			values = positional[i:]
+			variadic = true
+		}
+
+		for _, v := range values {
+			if err := cmdline.Check(a.Type, a.Choices, v); err != nil {
+				return fail("argument <%s>: %v", a.Name, err)
+			}
+		}
+	}
+
+	if !variadic && len(positional) > len(schema.Arguments) {
+		return fail("too many arguments")
+	}
+
+	return nil
+}
+
+// usageOf returns the usage of spec, one is put together from its schema if
+// the service didn't give one. path is the command line naming spec.
+func usageOf(path string, spec *proto.CommandSpec) string {
+	if len(spec.Usage) > 0 {
+		return spec.Usage
+	}
+
+	return cmdline.Usage(path, spec.Schema)
+}
+
+// describe renders the help of spec, path is the command line naming it
+func describe(path string, spec *proto.CommandSpec) string {
+	lines := []string{"usage: " + usageOf(path, spec)}
+	if len(spec.Description) > 0 {
+		lines = append(lines, spec.Description)
+	}
+
+	schema := spec.Schema
+	if schema == nil {
+		return strings.Join(lines, "\n")
+	}
+
+	if len(schema.Subcommands) > 0 {
+		lines = append(lines, "", "Subcommands:")
+		for _, s := range schema.Subcommands {
+			if !s.Hidden {
+				lines = append(lines, fmt.Sprintf("  %s - %s", s.Name, s.Description))
+			}
+		}
+	}
+
+	if len(schema.Arguments) > 0 {
+		lines = append(lines, "", "Arguments:")
+		for _, a := range schema.Arguments {
+			lines = append(lines, fmt.Sprintf("  %s (%s) - %s", a.Name, cmdline.Details(a.Type, a.Required, a.DefaultValue, a.Choices), a.Description))
+		}
+	}
+
+	if len(schema.Flags) > 0 {
+		lines = append(lines, "", "Flags:")
+		for _, f := range schema.Flags {
+			name := "--" + f.Name
+			if len(f.Short) > 0 {
+				name += ", -" + f.Short
+			}
+			lines = append(lines, fmt.Sprintf("  %s (%s) - %s", name, cmdline.Details(f.Type, f.Required, f.DefaultValue, f.Choices), f.Description))
+		}
+	}
+
+	if len(schema.Examples) > 0 {
+		lines = append(lines, "", "Examples:")
+		for _, e := range schema.Examples {
+			lines = append(lines, "  "+e)
+		}
+	}
+
+	if len(schema.Permission) > 0 {
+		lines = append(lines, "", "Requires: "+schema.Permission)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// helpFor returns the help of the command named by args
+func (b *bot) helpFor(args []string) ([]byte, error) {
+	if cmd, ok := b.internal[args[0]]; ok {
+		return []byte(fmt.Sprintf("%s - %s", cmd.usage, cmd.description)), nil
+	}
+
+	b.RLock()
+	commands := b.commands
+	info, ok := b.services[Namespace+"."+args[0]]
+	b.RUnlock()
+
+	if ok {
+		spec, n := findSpec(info.spec, args)
+		return []byte(describe(strings.Join(args[:n], " "), spec)), nil
+	}
+
+	if cmd, ok := commands.match([]byte(strings.Join(args, " "))); ok {
+		return []byte(fmt.Sprintf("%s - %s", cmd.Usage(), cmd.Description())), nil
+	}
+
+	return nil, b.unknownCommand(args[0])
+}
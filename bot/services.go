@@ -0,0 +1,245 @@
+package bot
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	protobuf "github.com/golang/protobuf/proto"
+	"github.com/micro/go-bot/input"
+	"github.com/micro/go-micro/metadata"
+	"golang.org/x/net/context"
+
+	proto "github.com/chremoas/chremoas/proto"
+	"github.com/chremoas/chremoas/render"
+)
+
+var (
+	// DefaultTimeout is how long a command may run when its service
+	// doesn't advertise a maximum runtime
+	DefaultTimeout = time.Minute
+	// DefaultNoticeAfter is how long a command may run before the bot lets
+	// the user know it is still working when its service doesn't advertise
+	// an expected runtime
+	DefaultNoticeAfter = 10 * time.Second
+)
+
+// stillWorkingMessage is sent when a command runs longer than expected
+var stillWorkingMessage = "Still working on it..."
+
+// serviceInfo is what the bot knows about a command service
+type serviceInfo struct {
+	help     string
+	spec     *proto.CommandSpec
+	expected time.Duration
+	timeout  time.Duration
+	// version is the protocol version of the service, 0 if it is from
+	// before versions
+	version int32
+	// capabilities are what the service supports, see negotiate
+	capabilities map[string]bool
+	// events are the chat event types the service subscribed to
+	events map[string]bool
+}
+
+func newServiceInfo(name string, rsp *proto.HelpResponse) *serviceInfo {
+	return &serviceInfo{
+		help: fmt.Sprintf("%s - %s", rsp.Usage, rsp.Description),
+		spec: &proto.CommandSpec{
+			Name:        name,
+			Usage:       rsp.Usage,
+			Description: rsp.Description,
+			Schema:      rsp.Schema,
+		},
+		expected: time.Duration(rsp.ExpectedRuntime) * time.Second,
+		timeout:  time.Duration(rsp.MaxRuntime) * time.Second,
+		version:  rsp.ProtocolVersion,
+	}
+}
+
+// supports reports whether the service has capability c
+func (s *serviceInfo) supports(c string) bool {
+	return s.capabilities[c]
+}
+
+// negotiate works out the capabilities of service from its help. Only the
+// capabilities a service advertises that the bot knows of are used, services
+// from before protocol versions have none. Mismatches are logged.
+func negotiate(service string, rsp *proto.HelpResponse) map[string]bool {
+	caps := make(map[string]bool)
+
+	if rsp.ProtocolVersion == 0 {
+		return caps
+	}
+
+	if rsp.ProtocolVersion > proto.ProtocolVersion {
+		log.Printf("[bot][watch] %s speaks protocol %d, newer than the bot's %d\n", service, rsp.ProtocolVersion, proto.ProtocolVersion)
+	}
+
+	known := make(map[string]bool)
+	for _, c := range proto.Capabilities {
+		known[c] = true
+	}
+
+	for _, c := range rsp.Capabilities {
+		if !known[c] {
+			log.Printf("[bot][watch] %s advertises unknown capability %s\n", service, c)
+			continue
+		}
+		caps[c] = true
+	}
+
+	return caps
+}
+
+// timeoutOr returns the maximum runtime of the service or def if it doesn't
+// advertise one.
+func (s *serviceInfo) timeoutOr(def time.Duration) time.Duration {
+	if s.timeout > 0 {
+		return s.timeout
+	}
+	return def
+}
+
+// noticeAfterOr returns how long to wait before telling the user the command
+// is still running, def is used if the service doesn't advertise an expected
+// runtime.
+func (s *serviceInfo) noticeAfterOr(def time.Duration) time.Duration {
+	if s.expected > 0 {
+		return s.expected
+	}
+	return def
+}
+
+// commandRegisterer is implemented by inputs that can offer commands to their
+// users natively, like discord's slash commands
+type commandRegisterer interface {
+	RegisterCommands(specs []*proto.CommandSpec) error
+}
+
+// specsOf returns the specs of the services that describe their arguments
+// sorted by name
+func specsOf(services map[string]*serviceInfo) []*proto.CommandSpec {
+	var specs []*proto.CommandSpec
+	for _, info := range services {
+		if info.spec.Schema != nil {
+			specs = append(specs, info.spec)
+		}
+	}
+
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].Name < specs[j].Name
+	})
+
+	return specs
+}
+
+func sameSpecs(a, b []*proto.CommandSpec) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !protobuf.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// registerCommands offers specs on the inputs that can offer commands
+// natively
+func (b *bot) registerCommands(specs []*proto.CommandSpec) {
+	for _, io := range b.inputs {
+		r, ok := io.(commandRegisterer)
+		if !ok {
+			continue
+		}
+
+		if err := r.RegisterCommands(specs); err != nil {
+			log.Println("[bot][watch] error registering commands with", io.String(), err)
+		}
+	}
+}
+
+// listServices shows which protocol version and capabilities each command
+// service has
+func (b *bot) listServices(ev input.Event, args []string) ([]byte, error) {
+	if !b.isAdmin(ev.From) {
+		return []byte(notAdminMessage), nil
+	}
+
+	b.RLock()
+	services := b.services
+	b.RUnlock()
+
+	if len(services) == 0 {
+		return []byte("No command services"), nil
+	}
+
+	table := &proto.Table{Header: append([]string{"service", "version"}, proto.Capabilities...)}
+	for name, info := range services {
+		version := strconv.Itoa(int(info.version))
+		if info.version == 0 {
+			version = "legacy"
+		}
+
+		row := []string{strings.TrimPrefix(name, Namespace+"."), version}
+		for _, c := range proto.Capabilities {
+			if info.supports(c) {
+				row = append(row, "yes")
+			} else {
+				row = append(row, "-")
+			}
+		}
+		table.Rows = append(table.Rows, &proto.Row{Cells: row})
+	}
+
+	sort.Slice(table.Rows, func(i, j int) bool {
+		return table.Rows[i].Cells[0] < table.Rows[j].Cells[0]
+	})
+
+	return []byte(fmt.Sprintf("Bot protocol %d\n%s\n%s%s", proto.ProtocolVersion, codeFence, render.Table(table), codeFence)), nil
+}
+
+func copyServices(services map[string]*serviceInfo) map[string]*serviceInfo {
+	c := make(map[string]*serviceInfo, len(services))
+	for k, v := range services {
+		c[k] = v
+	}
+	return c
+}
+
+// authenticate returns the name of the service that made the request in ctx
+// from the token it sent
+func (b *bot) authenticate(ctx context.Context) (string, error) {
+	md, ok := metadata.FromContext(ctx)
+	if !ok {
+		return "", errors.New("missing " + proto.TokenHeader)
+	}
+
+	var token string
+	for k, v := range md {
+		if strings.EqualFold(k, proto.TokenHeader) {
+			token = v
+		}
+	}
+
+	if len(token) == 0 {
+		return "", errors.New("missing " + proto.TokenHeader)
+	}
+
+	for service, t := range b.tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			return service, nil
+		}
+	}
+
+	return "", errors.New("invalid " + proto.TokenHeader)
+}
@@ -19,3 +19,22 @@ func (s sortedCommands) Less(i, j int) bool {
 func (s sortedCommands) Swap(i, j int) {
 	s.commands[i], s.commands[j] = s.commands[j], s.commands[i]
 }
+
+type byPriority struct {
+	commands commandTable
+}
+
+func (s byPriority) Len() int {
+	return len(s.commands)
+}
+
+func (s byPriority) Less(i, j int) bool {
+	if s.commands[i].priority != s.commands[j].priority {
+		return s.commands[i].priority > s.commands[j].priority
+	}
+	return s.commands[i].pattern < s.commands[j].pattern
+}
+
+func (s byPriority) Swap(i, j int) {
+	s.commands[i], s.commands[j] = s.commands[j], s.commands[i]
+}
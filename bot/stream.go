@@ -0,0 +1,283 @@
+package bot
+
+import (
+	"errors"
+	"io"
+	"log"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/micro/go-bot/input"
+	"golang.org/x/net/context"
+
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+var (
+	// EditInterval is how often the message showing the progress of a
+	// streamed command is edited
+	EditInterval = time.Second
+	// FollowUpInterval is how often progress of a streamed command is
+	// posted on inputs that can't edit messages
+	FollowUpInterval = 5 * time.Second
+)
+
+// errStreamed is returned for commands whose output was already sent while
+// they ran
+var errStreamed = errors.New("streamed")
+
+// stillWorking lets the user know the command is still running once d has
+// passed. The returned func stops the notice, it can be called more than
+// once.
+func stillWorking(c input.Conn, ev input.Event, d time.Duration) func() {
+	done := make(chan struct{})
+	noticed := make(chan struct{})
+	go func() {
+		defer close(noticed)
+
+		t := time.NewTimer(d)
+		defer t.Stop()
+
+		select {
+		case <-done:
+		case <-t.C:
+			if err := reply(c, ev, []byte(stillWorkingMessage)); err != nil {
+				log.Println("[bot][process] error sending notice", err)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+		<-noticed
+	}
+}
+
+// execStream runs a command through ExecStream, update is called for every
+// update the service sends. The response has all the output sent.
+func (b *bot) execStream(ctx context.Context, service string, exec *proto.ExecRequest, update func(*proto.ExecStreamResponse)) (*proto.ExecResponse, error) {
+	stream, err := proto.NewCommandStreamService(service, b.service.Client()).ExecStream(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	rsp := &proto.ExecResponse{}
+	for {
+		u, err := stream.Recv()
+		if err == io.EOF {
+			return rsp, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rsp.Result = append(rsp.Result, u.Result...)
+		if u.Rich != nil {
+			rsp.Rich = u.Rich
+		}
+		if len(u.Error) > 0 {
+			rsp.Error = u.Error
+			return rsp, nil
+		}
+
+		update(u)
+	}
+}
+
+// streamReporter shows the progress of a streamed command. Inputs that can
+// edit messages get a single message that is kept up to date, others get
+// follow ups with the output sent since the last one. Either way updates
+// are throttled.
+type streamReporter struct {
+	c        input.Conn
+	ev       input.Event
+	editor   editor
+	limit    int
+	interval time.Duration
+
+	sync.Mutex
+	result []byte
+	status string
+	// sent is how much of result was posted as follow ups
+	sent int
+	// id is the message being edited
+	id    string
+	shown bool
+	last  time.Time
+	timer *time.Timer
+	done  bool
+}
+
+func newStreamReporter(c input.Conn, ev input.Event, name string) *streamReporter {
+	r := &streamReporter{
+		c:        c,
+		ev:       ev,
+		limit:    MessageLimits[name],
+		interval: FollowUpInterval,
+	}
+
+	if ed, ok := editorOf(c); ok {
+		r.editor = ed
+		r.interval = EditInterval
+	}
+
+	return r
+}
+
+func (r *streamReporter) update(u *proto.ExecStreamResponse) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.result = append(r.result, u.Result...)
+	if len(u.Status) > 0 {
+		r.status = u.Status
+	}
+
+	if r.timer != nil || r.done {
+		return
+	}
+
+	wait := r.interval - time.Since(r.last)
+	if wait < 0 {
+		wait = 0
+	}
+	r.timer = time.AfterFunc(wait, r.flush)
+}
+
+func (r *streamReporter) flush() {
+	r.Lock()
+	defer r.Unlock()
+
+	r.timer = nil
+	if r.done {
+		return
+	}
+
+	r.last = time.Now()
+	if err := r.show(); err != nil {
+		log.Println("[bot][stream] error showing progress", err)
+	}
+}
+
+// show posts the progress so far, callers must hold the lock
+func (r *streamReporter) show() error {
+	var text string
+
+	if r.editor != nil {
+		text = r.tail(string(r.result))
+		if len(r.status) > 0 {
+			text = r.tail(text + "\n_" + r.status + "_")
+		}
+	} else {
+		text = string(r.result[r.sent:])
+		if len(r.status) > 0 {
+			text += "\n_" + r.status + "_"
+		}
+		r.sent = len(r.result)
+	}
+
+	if len(text) == 0 {
+		return nil
+	}
+	r.shown = true
+
+	ev := replyTo(r.ev, []byte(text))
+	switch {
+	case r.editor == nil:
+		return r.c.Send(ev)
+	case len(r.id) == 0:
+		id, err := r.editor.SendEditable(ev)
+		r.id = id
+		return err
+	default:
+		return r.editor.Edit(ev, r.id)
+	}
+}
+
+// tail cuts the start off text so it fits in a single message
+func (r *streamReporter) tail(text string) string {
+	const more = "…\n"
+
+	if r.limit == 0 || len(text) <= r.limit {
+		return text
+	}
+
+	cut := len(text) - r.limit + len(more)
+	for cut < len(text) && !utf8.RuneStart(text[cut]) {
+		cut++
+	}
+
+	return more + text[cut:]
+}
+
+// close stops showing progress, the status is taken off the message being
+// edited
+func (r *streamReporter) close() {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.done {
+		return
+	}
+	r.done = true
+
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+
+	if len(r.id) > 0 && len(r.status) > 0 {
+		r.status = ""
+		if err := r.show(); err != nil {
+			log.Println("[bot][stream] error showing progress", err)
+		}
+	}
+}
+
+// finish stops showing progress and returns what is left to send of rsp.
+// errStreamed is returned if the output was already sent in full.
+func (r *streamReporter) finish(rsp *proto.ExecResponse) ([]byte, *proto.RichResponse, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.done = true
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+
+	// nothing was shown yet, the response is sent as usual
+	if !r.shown {
+		return rsp.Result, rsp.Rich, nil
+	}
+
+	r.result = rsp.Result
+	r.status = ""
+
+	if r.editor != nil {
+		// the full output doesn't fit in the message being edited
+		if rsp.Rich != nil || (r.limit > 0 && len(rsp.Result) > r.limit) {
+			return rsp.Result, rsp.Rich, nil
+		}
+
+		if err := r.show(); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, errStreamed
+	}
+
+	if len(r.result) > r.sent {
+		if err := r.show(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if rsp.Rich != nil {
+		return nil, rsp.Rich, nil
+	}
+
+	return nil, nil, errStreamed
+}
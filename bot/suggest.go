@@ -0,0 +1,164 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/micro/go-bot/input"
+)
+
+// maxSuggestions is the most commands suggested for an unknown command
+const maxSuggestions = 3
+
+// knownCommands returns the name of every command the bot can run
+func (b *bot) knownCommands() []string {
+	seen := make(map[string]bool)
+
+	for name := range b.internal {
+		seen[name] = true
+	}
+
+	for _, name := range b.aliases.names() {
+		seen[name] = true
+	}
+
+	b.RLock()
+	for _, cmd := range b.commands.commands() {
+		seen[cmd.String()] = true
+	}
+	for service := range b.services {
+		seen[strings.TrimPrefix(service, Namespace+".")] = true
+	}
+	b.RUnlock()
+
+	var names []string
+	for name := range seen {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// suggest returns the known commands closest to name, closest first
+func (b *bot) suggest(name string) []string {
+	// allow roughly one typo for every three characters
+	max := len(name) / 3
+	if max < 1 {
+		max = 1
+	}
+
+	type candidate struct {
+		name     string
+		distance int
+	}
+
+	var candidates []candidate
+	for _, known := range b.knownCommands() {
+		if d := levenshtein(strings.ToLower(name), strings.ToLower(known)); d <= max {
+			candidates = append(candidates, candidate{known, d})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].name < candidates[j].name
+	})
+
+	var names []string
+	for i := 0; i < len(candidates) && i < maxSuggestions; i++ {
+		names = append(names, candidates[i].name)
+	}
+
+	return names
+}
+
+// unknownCommandError is returned for commands the bot doesn't know
+type unknownCommandError struct {
+	message string
+}
+
+func (e *unknownCommandError) Error() string {
+	return e.message
+}
+
+// unknownCommand builds the error for a command the bot doesn't know
+func (b *bot) unknownCommand(name string) error {
+	suggestions := b.suggest(name)
+	if len(suggestions) == 0 {
+		return &unknownCommandError{fmt.Sprintf("Unknown command %s, try help", name)}
+	}
+
+	return &unknownCommandError{fmt.Sprintf("Unknown command %s, did you mean %s?", name, strings.Join(suggestions, ", "))}
+}
+
+// isQuiet reports whether unknown commands are ignored in the channel of ev
+func (b *bot) isQuiet(ev input.Event) bool {
+	b.RLock()
+	defer b.RUnlock()
+	return b.quiet[channelOf(ev.From)]
+}
+
+func (b *bot) suggestions(ev input.Event, args []string) ([]byte, error) {
+	channel := channelOf(ev.From)
+
+	if len(args) == 1 {
+		if b.isQuiet(ev) {
+			return []byte("Suggestions are off in this channel"), nil
+		}
+		return []byte("Suggestions are on in this channel"), nil
+	}
+
+	if !b.isAdmin(ev.From) {
+		return []byte(notAdminMessage), nil
+	}
+
+	switch args[1] {
+	case "on":
+		b.Lock()
+		delete(b.quiet, channel)
+		b.Unlock()
+		return []byte("Suggestions are now on in this channel"), nil
+	case "off":
+		b.Lock()
+		b.quiet[channel] = true
+		b.Unlock()
+		return []byte("Suggestions are now off in this channel"), nil
+	}
+
+	return nil, fmt.Errorf("not a valid subcommand: %s", args[1])
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, minInt(cur[j-1]+1, prev[j-1]+cost))
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,157 @@
+package bot
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+const codeFence = "```"
+
+// Chain operators, see parseChain
+const (
+	opSequence = ';'
+	opPipe     = '|'
+)
+
+// token is an argument or, when op is set, a chain operator. start and end
+// are rune offsets of the token in the text it was read from.
+type token struct {
+	text  string
+	op    bool
+	start int
+	end   int
+}
+
+// tokenize splits a command line into arguments the way a shell would.
+//
+// Runs of whitespace separate arguments. Single quotes preserve everything up
+// to the closing quote, double quotes preserve everything except backslash
+// escapes, and a backslash outside of quotes escapes the next character. A
+// single quote only starts quoting at the start of an argument or a flag
+// value and when it is closed, others are apostrophes like in don't and are
+// kept as they are. A fenced code block is passed through verbatim, fences
+// included, as a single argument.
+func tokenize(text string) ([]string, error) {
+	tokens, err := lex(text, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var args []string
+	for _, t := range tokens {
+		args = append(args, t.text)
+	}
+
+	return args, nil
+}
+
+// lex splits text into tokens as described by tokenize. If ops is set
+// unquoted chain operators with whitespace on both sides are returned as
+// tokens of their own, ones touching other text like in a;b are literal.
+func lex(text string, ops bool) ([]token, error) {
+	var (
+		tokens  []token
+		current strings.Builder
+		inToken bool
+		start   int
+	)
+
+	begin := func(i int) {
+		if !inToken {
+			inToken = true
+			start = i
+		}
+	}
+
+	flush := func(end int) {
+		if inToken {
+			tokens = append(tokens, token{text: current.String(), start: start, end: end})
+			current.Reset()
+			inToken = false
+		}
+	}
+
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case isFence(runes, i):
+			end := i + len(codeFence)
+			for end < len(runes) && !isFence(runes, end) {
+				end++
+			}
+			if end == len(runes) {
+				return nil, errors.New("unterminated code block")
+			}
+			end += len(codeFence)
+			flush(i)
+			tokens = append(tokens, token{text: string(runes[i:end]), start: i, end: end})
+			i = end - 1
+		case unicode.IsSpace(r):
+			flush(i)
+		case ops && (r == opSequence || r == opPipe) && standalone(runes, i):
+			flush(i)
+			tokens = append(tokens, token{text: string(r), op: true, start: i, end: i + 1})
+		case r == '\\':
+			if i+1 == len(runes) {
+				return nil, errors.New("trailing backslash")
+			}
+			begin(i)
+			i++
+			current.WriteRune(runes[i])
+		case r == '\'' && quotes(runes, i, inToken):
+			end := indexRune(runes, i+1, '\'')
+			begin(i)
+			current.WriteString(string(runes[i+1 : end]))
+			i = end
+		case r == '"':
+			begin(i)
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) && (runes[i+1] == '"' || runes[i+1] == '\\') {
+					i++
+				}
+				current.WriteRune(runes[i])
+			}
+			if i == len(runes) {
+				return nil, errors.New("unterminated double quote")
+			}
+		default:
+			begin(i)
+			current.WriteRune(r)
+		}
+	}
+
+	flush(len(runes))
+	return tokens, nil
+}
+
+// quotes reports whether the single quote at i starts a quoted string rather
+// than being an apostrophe
+func quotes(runes []rune, i int, inToken bool) bool {
+	if inToken && runes[i-1] != '=' {
+		return false
+	}
+	return indexRune(runes, i+1, '\'') != -1
+}
+
+// standalone reports whether the rune at i has whitespace, or the start or
+// end of the text, on both sides
+func standalone(runes []rune, i int) bool {
+	return (i == 0 || unicode.IsSpace(runes[i-1])) && (i+1 == len(runes) || unicode.IsSpace(runes[i+1]))
+}
+
+func isFence(runes []rune, i int) bool {
+	return i+len(codeFence) <= len(runes) && string(runes[i:i+len(codeFence)]) == codeFence
+}
+
+func indexRune(runes []rune, from int, r rune) int {
+	for i := from; i < len(runes); i++ {
+		if runes[i] == r {
+			return i
+		}
+	}
+	return -1
+}
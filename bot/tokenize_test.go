@@ -0,0 +1,41 @@
+package bot
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		text string
+		args []string
+		err  bool
+	}{
+		{text: "role add  foo", args: []string{"role", "add", "foo"}},
+		{text: "  ", args: nil},
+		{text: `sig create "Test Sig" 'Some Name'`, args: []string{"sig", "create", "Test Sig", "Some Name"}},
+		{text: `say "a \"quoted\" word"`, args: []string{"say", `a "quoted" word`}},
+		{text: `say a\ b`, args: []string{"say", "a b"}},
+		{text: "say don't", args: []string{"say", "don't"}},
+		{text: "whois O'Brien and O'Neill", args: []string{"whois", "O'Brien", "and", "O'Neill"}},
+		{text: "say 'tis", args: []string{"say", "'tis"}},
+		{text: "filter add --name='foo bar'", args: []string{"filter", "add", "--name=foo bar"}},
+		{text: "say it's 'quoted here'", args: []string{"say", "it's", "quoted here"}},
+		{text: "paste ```go\nfmt.Println(\"a b\")\n```", args: []string{"paste", "```go\nfmt.Println(\"a b\")\n```"}},
+		{text: "echo a;b", args: []string{"echo", "a;b"}},
+		{text: `say "open`, err: true},
+		{text: "paste ```open", err: true},
+		{text: `say \`, err: true},
+	}
+
+	for _, tt := range tests {
+		args, err := tokenize(tt.text)
+		if (err != nil) != tt.err {
+			t.Errorf("tokenize(%q) error = %v, want error %v", tt.text, err, tt.err)
+			continue
+		}
+		if !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("tokenize(%q) = %q, want %q", tt.text, args, tt.args)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Package cmdline has what the bot and the args package share to check
+// command lines against command schemas and describe them
+package cmdline
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+// IsFlag reports whether arg looks like a flag rather than a value, negative
+// numbers are values
+func IsFlag(arg string) bool {
+	if len(arg) < 2 || arg[0] != '-' {
+		return false
+	}
+	_, err := strconv.ParseFloat(arg, 64)
+	return err != nil
+}
+
+// SplitFlag splits --name=value into its name and value
+func SplitFlag(arg string) (string, string, bool) {
+	arg = strings.TrimLeft(arg, "-")
+	if i := strings.Index(arg, "="); i != -1 {
+		return arg[:i], arg[i+1:], true
+	}
+	return arg, "", false
+}
+
+// Check checks value is of type t and one of choices if there are any
+func Check(t proto.ArgumentType, choices []string, value string) error {
+	if len(choices) > 0 {
+		found := false
+		for _, c := range choices {
+			if c == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s is not one of %s", value, strings.Join(choices, ", "))
+		}
+	}
+
+	var err error
+	switch t {
+	case proto.ArgumentType_INT:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case proto.ArgumentType_NUMBER:
+		_, err = strconv.ParseFloat(value, 64)
+	case proto.ArgumentType_BOOL:
+		_, err = strconv.ParseBool(value)
+	case proto.ArgumentType_DURATION:
+		_, err = time.ParseDuration(value)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s is not a valid %s", value, TypeName(t))
+	}
+
+	return nil
+}
+
+// TypeName is how t is shown to users
+func TypeName(t proto.ArgumentType) string {
+	return strings.ToLower(t.String())
+}
+
+// Usage puts together how a command with schema is used, path is the
+// command line naming it
+func Usage(path string, schema *proto.CommandSchema) string {
+	parts := []string{path}
+	if schema == nil {
+		return path
+	}
+
+	if len(schema.Subcommands) > 0 {
+		parts = append(parts, "<subcommand>")
+	}
+
+	for _, f := range schema.Flags {
+		flag := "--" + f.Name
+		if f.Type != proto.ArgumentType_BOOL {
+			flag += " <" + TypeName(f.Type) + ">"
+		}
+		if !f.Required {
+			flag = "[" + flag + "]"
+		}
+		parts = append(parts, flag)
+	}
+
+	for _, a := range schema.Arguments {
+		arg := a.Name
+		if a.Variadic {
+			arg += "..."
+		}
+		if a.Required {
+			arg = "<" + arg + ">"
+		} else {
+			arg = "[" + arg + "]"
+		}
+		parts = append(parts, arg)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Details describes the type and constraints of an argument or flag
+func Details(t proto.ArgumentType, required bool, def string, choices []string) string {
+	parts := []string{TypeName(t)}
+	if required {
+		parts = append(parts, "required")
+	}
+	if len(def) > 0 {
+		parts = append(parts, "default "+def)
+	}
+	if len(choices) > 0 {
+		parts = append(parts, "one of "+strings.Join(choices, ", "))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,46 @@
+// Package events has the types of the chat events inputs pass to the bot
+// besides commands. Inputs set the event type to one of these and put a
+// proto ChatEvent with the details in the meta under Key.
+package events
+
+// Key is the meta key of the ChatEvent
+const Key = "event"
+
+// Component is the type of events for components of a response being used,
+// the meta has a proto InteractRequest under ComponentKey instead of a
+// ChatEvent
+const (
+	Component    = "component"
+	ComponentKey = "component"
+)
+
+// Keys of the meta of text events saying where a command came from. The bot
+// takes the channel and user from the event's From when they are missing.
+const (
+	// GuildKey has the id of the guild or team as a string
+	GuildKey = "guild"
+	// ChannelKey has the id of the channel as a string
+	ChannelKey = "channel"
+	// UserKey has the id of the user as a string
+	UserKey = "user"
+	// NameKey has the name the user is shown with as a string
+	NameKey = "name"
+	// MessageKey has the id of the message as a string
+	MessageKey = "message"
+	// ThreadKey has the id of the thread the message is in as a string
+	ThreadKey = "thread"
+	// DirectKey is true for direct messages
+	DirectKey = "direct"
+	// TimeKey has the time the message was sent as a time.Time
+	TimeKey = "time"
+)
+
+const (
+	MemberJoin     = "member_join"
+	MemberLeave    = "member_leave"
+	ReactionAdd    = "reaction_add"
+	ReactionRemove = "reaction_remove"
+	MessageEdit    = "message_edit"
+	MessageDelete  = "message_delete"
+	Presence       = "presence"
+)
@@ -3,16 +3,17 @@ module github.com/chremoas/chremoas
 go 1.14
 
 require (
+	github.com/bwmarrin/discordgo v0.27.1
 	github.com/chremoas/services-common v1.3.2
 	github.com/golang/protobuf v1.3.2
-	github.com/lusis/go-slackbot v0.0.0-20180109053408-401027ccfef5 // indirect
-	github.com/lusis/slack-test v0.0.0-20190426140909-c40012f20018 // indirect
 	github.com/micro/cli v0.2.0
 	github.com/micro/go-bot v1.1.0
 	github.com/micro/go-micro v1.9.1
+	github.com/micro/hipchat v0.0.0-20160328000638-4c67119ac956
 	github.com/micro/micro v1.8.0
+	github.com/nlopes/slack v0.6.0
 	go.uber.org/zap v1.10.0
-	golang.org/x/net v0.0.0-20190724013045-ca1201d0de80
+	golang.org/x/net v0.0.0-20210226172049-e18ecbb05110
 )
 
 replace github.com/chremoas/chremoas => ../chremoas
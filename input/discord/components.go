@@ -0,0 +1,102 @@
+package discord
+
+import (
+	"log"
+
+	"github.com/bwmarrin/discordgo"
+
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+// Limits discord puts on message components
+const (
+	maxRows       = 5
+	maxButtons    = 5
+	maxSelections = 25
+
+	maxButtonLabel  = 80
+	maxPlaceholder  = 150
+	maxOptionLabel  = 100
+	maxOptionDetail = 100
+)
+
+// messageComponents lays components out in rows, buttons share rows and a
+// select menu takes up a row of its own
+func messageComponents(components []*proto.Component) []discordgo.MessageComponent {
+	rows := []discordgo.MessageComponent{}
+	var buttons []discordgo.MessageComponent
+
+	flush := func() {
+		if len(buttons) > 0 {
+			rows = append(rows, discordgo.ActionsRow{Components: buttons})
+			buttons = nil
+		}
+	}
+
+	for _, c := range components {
+		if c.Type == proto.ComponentType_SELECT {
+			flush()
+			rows = append(rows, discordgo.ActionsRow{Components: []discordgo.MessageComponent{selectMenu(c)}})
+			continue
+		}
+
+		if len(buttons) == maxButtons {
+			flush()
+		}
+		buttons = append(buttons, discordgo.Button{
+			Label:    label(c.Label, c.Id, maxButtonLabel),
+			Style:    buttonStyle(c.Style),
+			CustomID: c.Id,
+		})
+	}
+	flush()
+
+	if len(rows) > maxRows {
+		log.Printf("[discord] only the first %d rows of %d components can be shown\n", maxRows, len(components))
+		rows = rows[:maxRows]
+	}
+
+	return rows
+}
+
+func selectMenu(c *proto.Component) discordgo.SelectMenu {
+	menu := discordgo.SelectMenu{
+		CustomID:    c.Id,
+		Placeholder: truncate(c.Placeholder, maxPlaceholder),
+	}
+
+	for _, o := range c.Options {
+		if len(menu.Options) == maxSelections {
+			break
+		}
+		menu.Options = append(menu.Options, discordgo.SelectMenuOption{
+			Label:       label(o.Label, o.Value, maxOptionLabel),
+			Value:       o.Value,
+			Description: truncate(o.Description, maxOptionDetail),
+		})
+	}
+
+	return menu
+}
+
+// label returns text cut to n characters, or id if text is empty since
+// discord requires a label
+func label(text, id string, n int) string {
+	if len(text) == 0 {
+		text = id
+	}
+	return truncate(text, n)
+}
+
+func buttonStyle(s proto.ButtonStyle) discordgo.ButtonStyle {
+	switch s {
+	case proto.ButtonStyle_SECONDARY:
+		return discordgo.SecondaryButton
+	case proto.ButtonStyle_SUCCESS:
+		return discordgo.SuccessButton
+	case proto.ButtonStyle_DANGER:
+		return discordgo.DangerButton
+	default:
+		return discordgo.PrimaryButton
+	}
+}
@@ -0,0 +1,453 @@
+package discord
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/micro/go-bot/input"
+
+	"github.com/chremoas/chremoas/events"
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+// originalResponse is the id SendEditable returns when it answered a slash
+// command
+const originalResponse = "@original"
+
+type discordConn struct {
+	master *discordInput
+	exit   chan struct{}
+	recv   chan *received
+
+	sync.Mutex
+	// remove the handlers added to the session for the connection
+	removers []func()
+}
+
+// received is a message, or a slash command or use of a component turned
+// into one, or another chat event
+type received struct {
+	msg         *discordgo.Message
+	interaction *interaction
+	component   *proto.InteractRequest
+	event       *proto.ChatEvent
+	// prefix is what the user typed before the command
+	prefix string
+}
+
+// interaction is a slash command or use of a component being answered, the
+// first reply to it replaces the deferred response
+type interaction struct {
+	*discordgo.Interaction
+
+	sync.Mutex
+	answered bool
+}
+
+// answering returns the slash command e replies to if e is the first reply
+func answering(e *input.Event) (*interaction, bool) {
+	it, ok := e.Meta["interaction"].(*interaction)
+	if !ok {
+		return nil, false
+	}
+
+	it.Lock()
+	defer it.Unlock()
+
+	if it.answered {
+		return nil, false
+	}
+	it.answered = true
+
+	return it, true
+}
+
+// release lets the next reply answer the interaction after a failed one
+func (it *interaction) release() {
+	it.Lock()
+	it.answered = false
+	it.Unlock()
+}
+
+func newConn(master *discordInput) *discordConn {
+	conn := &discordConn{
+		master: master,
+		exit:   make(chan struct{}),
+		recv:   make(chan *received),
+	}
+
+	conn.handle(func(s *discordgo.Session, m *discordgo.MessageCreate) {
+		if m.Author.ID == master.botID {
+			return
+		}
+
+		if !master.allowed(m.Author.ID) {
+			return
+		}
+
+		content, valid := conn.master.prefixfn(m.Message.Content)
+		if !valid {
+			return
+		}
+
+		prefix := strings.TrimSuffix(m.Message.Content, content)
+		m.Message.Content = content
+
+		conn.deliver(&received{msg: m.Message, prefix: prefix})
+	})
+
+	conn.handle(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		switch i.Type {
+		case discordgo.InteractionApplicationCommand,
+			discordgo.InteractionApplicationCommandAutocomplete,
+			discordgo.InteractionMessageComponent:
+		default:
+			return
+		}
+
+		user := i.User
+		if i.Member != nil {
+			user = i.Member.User
+		}
+
+		if user == nil || !master.allowed(user.ID) {
+			return
+		}
+
+		if i.Type == discordgo.InteractionApplicationCommandAutocomplete {
+			master.autocomplete(s, i, user)
+			return
+		}
+
+		var (
+			text      string
+			component *proto.InteractRequest
+		)
+
+		if i.Type == discordgo.InteractionMessageComponent {
+			data := i.MessageComponentData()
+			component = &proto.InteractRequest{ComponentId: data.CustomID, Values: data.Values}
+		} else {
+			var ok bool
+			if text, _, ok = master.commandLine(i.ApplicationCommandData()); !ok {
+				return
+			}
+		}
+
+		// discord wants an answer within 3 seconds, the response follows
+		// once the command has run
+		err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		})
+		if err != nil {
+			log.Println("[discord] error acknowledging interaction", err)
+			return
+		}
+
+		conn.deliver(&received{
+			msg:         interactionMessage(i, user, text),
+			interaction: &interaction{Interaction: i.Interaction},
+			component:   component,
+			prefix:      "/",
+		})
+	})
+
+	conn.addEventHandlers()
+
+	return conn
+}
+
+// handle adds h to the session until the connection is closed
+func (dc *discordConn) handle(h interface{}) {
+	dc.Lock()
+	dc.removers = append(dc.removers, dc.master.session.AddHandler(h))
+	dc.Unlock()
+}
+
+// deliver passes r on to Recv, or drops it once the connection is closed
+func (dc *discordConn) deliver(r *received) {
+	select {
+	case <-dc.exit:
+	case dc.recv <- r:
+	}
+}
+
+// stop removes the handlers of the connection and ends Recv
+func (dc *discordConn) stop() {
+	dc.Lock()
+	defer dc.Unlock()
+
+	for _, remove := range dc.removers {
+		remove()
+	}
+	dc.removers = nil
+
+	select {
+	case <-dc.exit:
+	default:
+		close(dc.exit)
+	}
+}
+
+// addEventHandlers passes chat events other than messages on to the bot
+func (dc *discordConn) addEventHandlers() {
+	dc.handle(func(s *discordgo.Session, m *discordgo.GuildMemberAdd) {
+		dc.emit(&proto.ChatEvent{Type: events.MemberJoin, GuildId: m.GuildID, UserId: m.User.ID})
+	})
+
+	dc.handle(func(s *discordgo.Session, m *discordgo.GuildMemberRemove) {
+		dc.emit(&proto.ChatEvent{Type: events.MemberLeave, GuildId: m.GuildID, UserId: m.User.ID})
+	})
+
+	dc.handle(func(s *discordgo.Session, r *discordgo.MessageReactionAdd) {
+		dc.emit(&proto.ChatEvent{
+			Type:      events.ReactionAdd,
+			GuildId:   r.GuildID,
+			ChannelId: r.ChannelID,
+			UserId:    r.UserID,
+			MessageId: r.MessageID,
+			Emoji:     r.Emoji.APIName(),
+		})
+	})
+
+	dc.handle(func(s *discordgo.Session, r *discordgo.MessageReactionRemove) {
+		dc.emit(&proto.ChatEvent{
+			Type:      events.ReactionRemove,
+			GuildId:   r.GuildID,
+			ChannelId: r.ChannelID,
+			UserId:    r.UserID,
+			MessageId: r.MessageID,
+			Emoji:     r.Emoji.APIName(),
+		})
+	})
+
+	dc.handle(func(s *discordgo.Session, m *discordgo.MessageUpdate) {
+		// updates without an author are embeds being filled in
+		if m.Author == nil {
+			return
+		}
+
+		dc.emit(&proto.ChatEvent{
+			Type:      events.MessageEdit,
+			GuildId:   m.GuildID,
+			ChannelId: m.ChannelID,
+			UserId:    m.Author.ID,
+			MessageId: m.ID,
+			Text:      m.Content,
+		})
+	})
+
+	dc.handle(func(s *discordgo.Session, m *discordgo.MessageDelete) {
+		dc.emit(&proto.ChatEvent{
+			Type:      events.MessageDelete,
+			GuildId:   m.GuildID,
+			ChannelId: m.ChannelID,
+			MessageId: m.ID,
+		})
+	})
+
+	dc.handle(func(s *discordgo.Session, p *discordgo.PresenceUpdate) {
+		if p.User == nil {
+			return
+		}
+
+		dc.emit(&proto.ChatEvent{
+			Type:    events.Presence,
+			GuildId: p.GuildID,
+			UserId:  p.User.ID,
+			Status:  string(p.Status),
+		})
+	})
+}
+
+// emit passes ev on to the bot unless the bot caused it
+func (dc *discordConn) emit(ev *proto.ChatEvent) {
+	if ev.UserId == dc.master.botID {
+		return
+	}
+
+	dc.deliver(&received{event: ev})
+}
+
+func (dc *discordConn) Recv(event *input.Event) error {
+	for {
+		select {
+		case <-dc.exit:
+			return errors.New("connection closed")
+		case r := <-dc.recv:
+			if r.event != nil {
+				event.From = r.event.ChannelId + ":" + r.event.UserId
+				event.To = dc.master.botID
+				event.Type = input.EventType(r.event.Type)
+				event.Data = nil
+				event.Meta = map[string]interface{}{
+					events.Key: r.event,
+				}
+				return nil
+			}
+
+			msg := r.msg
+
+			event.From = msg.ChannelID + ":" + msg.Author.ID
+			event.To = dc.master.botID
+			event.Type = input.TextEvent
+			event.Data = []byte(msg.Content)
+			event.Meta = map[string]interface{}{
+				"reply":           msg,
+				"prefix":          r.prefix,
+				events.GuildKey:   msg.GuildID,
+				events.ChannelKey: msg.ChannelID,
+				events.UserKey:    msg.Author.ID,
+				events.NameKey:    msg.Author.Username,
+				events.MessageKey: msg.ID,
+				events.DirectKey:  len(msg.GuildID) == 0,
+				events.TimeKey:    msg.Timestamp,
+			}
+
+			if r.interaction != nil {
+				event.Meta["interaction"] = r.interaction
+			}
+
+			if r.component != nil {
+				event.Type = input.EventType(events.Component)
+				event.Data = nil
+				event.Meta[events.ComponentKey] = r.component
+			}
+
+			// the member has the user's nickname in the guild
+			if len(msg.GuildID) > 0 {
+				if member, err := dc.master.session.State.Member(msg.GuildID, msg.Author.ID); err == nil && len(member.Nick) > 0 {
+					event.Meta[events.NameKey] = member.Nick
+				}
+			}
+			return nil
+		}
+	}
+}
+
+func (dc *discordConn) Send(e *input.Event) error {
+	if it, ok := answering(e); ok {
+		return dc.respond(it, e)
+	}
+
+	fields := strings.Split(e.To, ":")
+	_, err := dc.master.session.ChannelMessageSend(fields[0], string(e.Data))
+	return err
+}
+
+// respond replaces the deferred response to a slash command with e
+func (dc *discordConn) respond(it *interaction, e *input.Event) error {
+	content := string(e.Data)
+	_, err := dc.master.session.InteractionResponseEdit(it.Interaction, &discordgo.WebhookEdit{Content: &content})
+	return err
+}
+
+// SendEditable sends e and returns the id of the message so it can be edited
+func (dc *discordConn) SendEditable(e *input.Event) (string, error) {
+	if it, ok := answering(e); ok {
+		return originalResponse, dc.respond(it, e)
+	}
+
+	fields := strings.Split(e.To, ":")
+	msg, err := dc.master.session.ChannelMessageSend(fields[0], string(e.Data))
+	if err != nil {
+		return "", err
+	}
+	return msg.ID, nil
+}
+
+// Edit replaces the content of message id with e
+func (dc *discordConn) Edit(e *input.Event, id string) error {
+	if it, ok := e.Meta["interaction"].(*interaction); ok && id == originalResponse {
+		return dc.respond(it, e)
+	}
+
+	fields := strings.Split(e.To, ":")
+	_, err := dc.master.session.ChannelMessageEdit(fields[0], id, string(e.Data))
+	return err
+}
+
+// SendFile sends data as a file attachment named name
+func (dc *discordConn) SendFile(e *input.Event, name string, data []byte) error {
+	if it, ok := answering(e); ok {
+		_, err := dc.master.session.InteractionResponseEdit(it.Interaction, &discordgo.WebhookEdit{
+			Files: []*discordgo.File{{Name: name, Reader: bytes.NewReader(data)}},
+		})
+		return err
+	}
+
+	fields := strings.Split(e.To, ":")
+	_, err := dc.master.session.ChannelFileSend(fields[0], name, bytes.NewReader(data))
+	return err
+}
+
+// RendersComponents reports that SendRich shows buttons and select menus
+func (dc *discordConn) RendersComponents() bool {
+	return true
+}
+
+// SendRich sends a rich response as an embed with its components
+func (dc *discordConn) SendRich(e *input.Event, rich *proto.RichResponse) error {
+	fields := strings.Split(e.To, ":")
+
+	embed, err := embedOf(rich)
+	if err != nil {
+		return err
+	}
+
+	var files []*discordgo.File
+	for _, a := range rich.Attachments {
+		files = append(files, &discordgo.File{
+			Name:        a.Name,
+			ContentType: a.ContentType,
+			Reader:      bytes.NewReader(a.Data),
+		})
+	}
+
+	// a response can be nothing but components, discord rejects empty
+	// embeds
+	embeds := []*discordgo.MessageEmbed{}
+	if len(embed.Title) > 0 || len(embed.Description) > 0 || len(embed.Fields) > 0 || embed.Image != nil || embed.Footer != nil {
+		embeds = append(embeds, embed)
+	}
+
+	components := messageComponents(rich.Components)
+
+	if it, ok := answering(e); ok {
+		_, err := dc.master.session.InteractionResponseEdit(it.Interaction, &discordgo.WebhookEdit{
+			Embeds:     &embeds,
+			Files:      files,
+			Components: &components,
+		})
+		if err != nil {
+			// whatever is sent instead still answers the slash command
+			it.release()
+		}
+		return err
+	}
+
+	_, err = dc.master.session.ChannelMessageSendComplex(fields[0], &discordgo.MessageSend{
+		Embeds:     embeds,
+		Files:      files,
+		Components: components,
+	})
+	return err
+}
+
+// DirectChannel returns the channel for direct messages with user
+func (dc *discordConn) DirectChannel(user string) (string, error) {
+	ch, err := dc.master.session.UserChannelCreate(user)
+	if err != nil {
+		return "", err
+	}
+	return ch.ID, nil
+}
+
+func (dc *discordConn) Close() error {
+	dc.stop()
+	return dc.master.session.Close()
+}
@@ -0,0 +1,206 @@
+// Package discord is a discord input for the bot, forked from
+// github.com/micro/go-bot/input/discord. The bot needs more from inputs than
+// go-bot's input.Conn has, like attachments, embeds, slash commands and
+// components, which the bot finds through interfaces of its own. Those don't
+// belong in go-bot and the go-bot release the bot builds against doesn't get
+// updates, so the input is kept here and registered in place of go-bot's.
+package discord
+
+import (
+	"fmt"
+	"sync"
+
+	"errors"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/micro/cli"
+	"github.com/micro/go-bot/input"
+
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+func init() {
+	input.Inputs["discord"] = newInput()
+}
+
+func newInput() *discordInput {
+	return &discordInput{}
+}
+
+type discordInput struct {
+	token     string
+	whitelist []string
+	prefix    string
+	prefixfn  func(string) (string, bool)
+	botID     string
+	slash     bool
+	intents   discordgo.Intent
+
+	session *discordgo.Session
+
+	sync.Mutex
+	running bool
+	// the current connection, replaced by every Stream
+	conn *discordConn
+	exit chan struct{}
+	// commands offered as slash commands keyed by name
+	commands map[string]*proto.CommandSpec
+	complete func(ev input.Event, text, argument, prefix string) ([]*proto.Suggestion, error)
+}
+
+func (d *discordInput) Flags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:   "discord_token",
+			EnvVar: "MICRO_DISCORD_TOKEN",
+			Usage:  "Discord token (prefix with Bot if it's for bot account)",
+		},
+		cli.StringFlag{
+			Name:   "discord_whitelist",
+			EnvVar: "MICRO_DISCORD_WHITELIST",
+			Usage:  "Discord Whitelist (seperated by ,)",
+		},
+		cli.StringFlag{
+			Name:   "discord_prefix",
+			Usage:  "Discord Prefix",
+			EnvVar: "MICRO_DISCORD_PREFIX",
+			Value:  "Micro ",
+		},
+		cli.BoolFlag{
+			Name:   "discord_privileged_intents",
+			Usage:  "Receive member and presence events, the intents have to be enabled for the bot in the developer portal",
+			EnvVar: "MICRO_DISCORD_PRIVILEGED_INTENTS",
+		},
+		cli.BoolFlag{
+			Name:   "discord_slash_commands",
+			Usage:  "Offer commands that describe their arguments as slash commands",
+			EnvVar: "MICRO_DISCORD_SLASH_COMMANDS",
+		},
+	}
+}
+
+func (d *discordInput) Init(ctx *cli.Context) error {
+	token := ctx.String("discord_token")
+	whitelist := ctx.String("discord_whitelist")
+	prefix := ctx.String("discord_prefix")
+
+	if len(token) == 0 {
+		return errors.New("require token")
+	}
+
+	d.token = token
+	d.prefix = prefix
+	d.slash = ctx.Bool("discord_slash_commands")
+
+	// commands are read from message content
+	d.intents = discordgo.IntentsAllWithoutPrivileged | discordgo.IntentMessageContent
+	if ctx.Bool("discord_privileged_intents") {
+		d.intents |= discordgo.IntentGuildMembers | discordgo.IntentGuildPresences
+	}
+
+	if len(whitelist) > 0 {
+		d.whitelist = strings.Split(whitelist, ",")
+	}
+
+	return nil
+}
+
+func (d *discordInput) Start() error {
+	if len(d.token) == 0 {
+		return errors.New("missing discord configuration")
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	if d.running {
+		return nil
+	}
+
+	var err error
+	d.session, err = discordgo.New(d.token)
+	if err != nil {
+		return err
+	}
+	d.session.Identify.Intents = d.intents
+
+	u, err := d.session.User("@me")
+	if err != nil {
+		return err
+	}
+
+	d.botID = u.ID
+	d.prefixfn = CheckPrefixFactory(fmt.Sprintf("<@%s> ", d.botID), fmt.Sprintf("<@!%s> ", d.botID), d.prefix)
+
+	d.exit = make(chan struct{})
+	d.running = true
+
+	return nil
+}
+
+func (d *discordInput) Stream() (input.Conn, error) {
+	d.Lock()
+	defer d.Unlock()
+	if !d.running {
+		return nil, errors.New("not running")
+	}
+
+	//Fire-n-forget close just in case...
+	d.session.Close()
+
+	// the handlers of the last connection would see every event again
+	if d.conn != nil {
+		d.conn.stop()
+	}
+
+	d.conn = newConn(d)
+	if err := d.session.Open(); err != nil {
+		return nil, err
+	}
+	return d.conn, nil
+}
+
+func (d *discordInput) Stop() error {
+	d.Lock()
+	defer d.Unlock()
+
+	if !d.running {
+		return nil
+	}
+
+	close(d.exit)
+	d.running = false
+	return nil
+}
+
+func (d *discordInput) String() string {
+	return "discord"
+}
+
+// allowed reports whether the user may use the bot
+func (d *discordInput) allowed(userID string) bool {
+	if len(d.whitelist) == 0 {
+		return true
+	}
+
+	for _, ID := range d.whitelist {
+		if userID == ID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckPrefixFactory Creates a prefix checking function and stuff.
+func CheckPrefixFactory(prefixes ...string) func(string) (string, bool) {
+	return func(content string) (string, bool) {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(content, prefix) {
+				return strings.TrimPrefix(content, prefix), true
+			}
+		}
+		return "", false
+	}
+}
@@ -0,0 +1,85 @@
+package discord
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+
+	proto "github.com/chremoas/chremoas/proto"
+	"github.com/chremoas/chremoas/render"
+)
+
+// Limits discord puts on embeds, in characters
+const (
+	maxTitle       = 256
+	maxEmbedText   = 4096
+	maxFields      = 25
+	maxFieldName   = 256
+	maxFieldValue  = 1024
+	maxFooter      = 2048
+	maxEmbedLength = 6000
+)
+
+// errTooLarge is returned for rich responses that don't fit in an embed, the
+// bot sends them as text instead
+var errTooLarge = errors.New("rich response is too large for an embed")
+
+// embedOf turns rich into an embed. Titles, field names and footers are cut
+// short to fit, text that doesn't fit makes it fail with errTooLarge.
+func embedOf(rich *proto.RichResponse) (*discordgo.MessageEmbed, error) {
+	description := rich.Text
+	for _, t := range rich.Tables {
+		description = append(description, "```\n"+render.Table(t)+"```")
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       truncate(rich.Title, maxTitle),
+		Description: strings.Join(description, "\n\n"),
+		Color:       int(rich.Colour),
+	}
+
+	if utf8.RuneCountInString(embed.Description) > maxEmbedText || len(rich.Fields) > maxFields {
+		return nil, errTooLarge
+	}
+
+	length := utf8.RuneCountInString(embed.Title) + utf8.RuneCountInString(embed.Description)
+
+	for _, f := range rich.Fields {
+		if utf8.RuneCountInString(f.Value) > maxFieldValue {
+			return nil, errTooLarge
+		}
+
+		field := &discordgo.MessageEmbedField{
+			Name:   truncate(f.Name, maxFieldName),
+			Value:  f.Value,
+			Inline: f.Inline,
+		}
+		embed.Fields = append(embed.Fields, field)
+		length += utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+	}
+
+	if len(rich.ImageUrl) > 0 {
+		embed.Image = &discordgo.MessageEmbedImage{URL: rich.ImageUrl}
+	}
+
+	if len(rich.Footer) > 0 {
+		embed.Footer = &discordgo.MessageEmbedFooter{Text: truncate(rich.Footer, maxFooter)}
+		length += utf8.RuneCountInString(embed.Footer.Text)
+	}
+
+	if length > maxEmbedLength {
+		return nil, errTooLarge
+	}
+
+	return embed, nil
+}
+
+// truncate cuts s short to at most n characters
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n-1]) + "…"
+}
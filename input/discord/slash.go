@@ -0,0 +1,413 @@
+package discord
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/micro/go-bot/input"
+
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+// Limits discord puts on slash commands
+const (
+	maxDescription = 100
+	maxOptions     = 25
+	maxChoices     = 25
+)
+
+var commandName = regexp.MustCompile(`^[-_a-z0-9]{1,32}$`)
+
+// RegisterCommands offers specs as slash commands, replacing the ones offered
+// before. Commands that can't be offered are logged and skipped.
+func (d *discordInput) RegisterCommands(specs []*proto.CommandSpec) error {
+	if !d.slash {
+		return nil
+	}
+
+	d.Lock()
+	session, running := d.session, d.running
+	d.Unlock()
+
+	if !running {
+		return errors.New("not running")
+	}
+
+	commands := make(map[string]*proto.CommandSpec)
+	cmds := []*discordgo.ApplicationCommand{}
+	for _, spec := range specs {
+		cmd, err := applicationCommand(spec)
+		if err != nil {
+			log.Printf("[discord] can't offer %s as a slash command: %v\n", spec.Name, err)
+			continue
+		}
+		cmds = append(cmds, cmd)
+		commands[spec.Name] = spec
+	}
+
+	if _, err := session.ApplicationCommandBulkOverwrite(d.botID, "", cmds); err != nil {
+		return err
+	}
+
+	d.Lock()
+	d.commands = commands
+	d.Unlock()
+
+	return nil
+}
+
+func applicationCommand(spec *proto.CommandSpec) (*discordgo.ApplicationCommand, error) {
+	if !commandName.MatchString(spec.Name) {
+		return nil, errors.New("invalid name")
+	}
+
+	options, err := commandOptions(spec, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &discordgo.ApplicationCommand{
+		Name:        spec.Name,
+		Description: description(spec.Description, spec.Name),
+		Options:     options,
+	}, nil
+}
+
+// commandOptions turns the schema of spec into options, depth is how deep
+// spec is in the subcommands
+func commandOptions(spec *proto.CommandSpec, depth int) ([]*discordgo.ApplicationCommandOption, error) {
+	schema := spec.Schema
+	if schema == nil {
+		return nil, nil
+	}
+
+	if len(schema.Subcommands) > 0 && (len(schema.Arguments) > 0 || len(schema.Flags) > 0) {
+		return nil, fmt.Errorf("%s has both subcommands and arguments", spec.Name)
+	}
+
+	var options []*discordgo.ApplicationCommandOption
+
+	for _, sub := range schema.Subcommands {
+		if sub.Hidden {
+			continue
+		}
+
+		if !commandName.MatchString(sub.Name) {
+			return nil, fmt.Errorf("invalid subcommand name %s", sub.Name)
+		}
+
+		typ := discordgo.ApplicationCommandOptionSubCommand
+		if sub.Schema != nil && len(sub.Schema.Subcommands) > 0 {
+			typ = discordgo.ApplicationCommandOptionSubCommandGroup
+		}
+
+		// discord only has groups of subcommands
+		if depth > 1 || (depth == 1 && typ == discordgo.ApplicationCommandOptionSubCommandGroup) {
+			return nil, errors.New("subcommands are nested too deep")
+		}
+
+		subOptions, err := commandOptions(sub, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		options = append(options, &discordgo.ApplicationCommandOption{
+			Type:        typ,
+			Name:        sub.Name,
+			Description: description(sub.Description, sub.Name),
+			Options:     subOptions,
+		})
+	}
+
+	for _, a := range schema.Arguments {
+		options = append(options, option(a.Name, a.Description, a.Type, a.Required, a.Choices, a.Complete))
+	}
+
+	for _, f := range schema.Flags {
+		options = append(options, option(f.Name, f.Description, f.Type, f.Required, f.Choices, f.Complete))
+	}
+
+	for _, o := range options {
+		if !commandName.MatchString(o.Name) {
+			return nil, fmt.Errorf("invalid option name %s", o.Name)
+		}
+	}
+
+	if len(options) > maxOptions {
+		return nil, fmt.Errorf("more than %d options", maxOptions)
+	}
+
+	// discord wants required options first
+	sort.SliceStable(options, func(i, j int) bool {
+		return options[i].Required && !options[j].Required
+	})
+
+	return options, nil
+}
+
+func option(name, desc string, t proto.ArgumentType, required bool, choices []string, complete bool) *discordgo.ApplicationCommandOption {
+	o := &discordgo.ApplicationCommandOption{
+		Type:        optionType(t),
+		Name:        name,
+		Description: description(desc, name),
+		Required:    required,
+	}
+
+	for _, c := range choices {
+		choice := &discordgo.ApplicationCommandOptionChoice{Name: c, Value: c}
+		switch o.Type {
+		case discordgo.ApplicationCommandOptionInteger:
+			v, err := strconv.ParseInt(c, 10, 64)
+			if err != nil {
+				continue
+			}
+			choice.Value = v
+		case discordgo.ApplicationCommandOptionNumber:
+			v, err := strconv.ParseFloat(c, 64)
+			if err != nil {
+				continue
+			}
+			choice.Value = v
+		}
+		o.Choices = append(o.Choices, choice)
+	}
+
+	// discord can't have both choices and autocomplete
+	if complete && len(o.Choices) == 0 {
+		switch o.Type {
+		case discordgo.ApplicationCommandOptionString,
+			discordgo.ApplicationCommandOptionInteger,
+			discordgo.ApplicationCommandOptionNumber:
+			o.Autocomplete = true
+		}
+	}
+
+	return o
+}
+
+func optionType(t proto.ArgumentType) discordgo.ApplicationCommandOptionType {
+	switch t {
+	case proto.ArgumentType_INT:
+		return discordgo.ApplicationCommandOptionInteger
+	case proto.ArgumentType_NUMBER:
+		return discordgo.ApplicationCommandOptionNumber
+	case proto.ArgumentType_BOOL:
+		return discordgo.ApplicationCommandOptionBoolean
+	case proto.ArgumentType_USER:
+		return discordgo.ApplicationCommandOptionUser
+	case proto.ArgumentType_CHANNEL:
+		return discordgo.ApplicationCommandOptionChannel
+	default:
+		return discordgo.ApplicationCommandOptionString
+	}
+}
+
+// description returns desc cut to what discord allows, or name if desc is
+// empty since discord requires one
+func description(desc, name string) string {
+	if len(desc) == 0 {
+		return name
+	}
+
+	return truncate(desc, maxDescription)
+}
+
+// commandLine turns a slash command back into the command line a user would
+// have typed for it. The option being autocompleted is left out and
+// returned.
+func (d *discordInput) commandLine(data discordgo.ApplicationCommandInteractionData) (string, *discordgo.ApplicationCommandInteractionDataOption, bool) {
+	d.Lock()
+	spec, ok := d.commands[data.Name]
+	d.Unlock()
+
+	if !ok {
+		return "", nil, false
+	}
+
+	parts := []string{data.Name}
+	options := data.Options
+
+	for len(options) == 1 && (options[0].Type == discordgo.ApplicationCommandOptionSubCommand ||
+		options[0].Type == discordgo.ApplicationCommandOptionSubCommandGroup) {
+		spec = subcommand(spec, options[0].Name)
+		if spec == nil {
+			return "", nil, false
+		}
+		parts = append(parts, options[0].Name)
+		options = options[0].Options
+	}
+
+	if spec.Schema == nil {
+		return strings.Join(parts, " "), nil, true
+	}
+
+	var focused *discordgo.ApplicationCommandInteractionDataOption
+	values := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
+	for _, o := range options {
+		if o.Focused {
+			focused = o
+			continue
+		}
+		values[o.Name] = o
+	}
+
+	for _, f := range spec.Schema.Flags {
+		o, ok := values[f.Name]
+		if !ok {
+			continue
+		}
+
+		if f.Type == proto.ArgumentType_BOOL {
+			parts = append(parts, "--"+f.Name+"="+strconv.FormatBool(o.BoolValue()))
+			continue
+		}
+		parts = append(parts, "--"+f.Name+"="+quote(optionValue(o)))
+	}
+
+	for _, a := range spec.Schema.Arguments {
+		o, ok := values[a.Name]
+		if !ok {
+			continue
+		}
+
+		// variadic arguments are split the way they would be if typed
+		if a.Variadic {
+			parts = append(parts, optionValue(o))
+			continue
+		}
+		parts = append(parts, quote(optionValue(o)))
+	}
+
+	return strings.Join(parts, " "), focused, true
+}
+
+// HandleCompletions sets where suggestions for slash command autocomplete
+// come from
+func (d *discordInput) HandleCompletions(complete func(ev input.Event, text, argument, prefix string) ([]*proto.Suggestion, error)) {
+	d.Lock()
+	d.complete = complete
+	d.Unlock()
+}
+
+// autocomplete answers a slash command autocomplete with suggestions for the
+// option being typed
+func (d *discordInput) autocomplete(s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User) {
+	d.Lock()
+	complete := d.complete
+	d.Unlock()
+
+	if complete == nil {
+		return
+	}
+
+	text, focused, ok := d.commandLine(i.ApplicationCommandData())
+	if !ok || focused == nil {
+		return
+	}
+
+	ev := input.Event{
+		From: i.ChannelID + ":" + user.ID,
+		To:   d.botID,
+		Type: input.TextEvent,
+		Data: []byte(text),
+		Meta: map[string]interface{}{
+			"reply": interactionMessage(i, user, text),
+		},
+	}
+
+	suggestions, err := complete(ev, text, focused.Name, optionValue(focused))
+	if err != nil {
+		log.Println("[discord] error completing", text, err)
+	}
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, sg := range suggestions {
+		if len(choices) == maxChoices {
+			break
+		}
+
+		choice := &discordgo.ApplicationCommandOptionChoice{
+			Name:  description(sg.Label, sg.Value),
+			Value: sg.Value,
+		}
+
+		switch focused.Type {
+		case discordgo.ApplicationCommandOptionInteger:
+			v, err := strconv.ParseInt(sg.Value, 10, 64)
+			if err != nil {
+				continue
+			}
+			choice.Value = v
+		case discordgo.ApplicationCommandOptionNumber:
+			v, err := strconv.ParseFloat(sg.Value, 64)
+			if err != nil {
+				continue
+			}
+			choice.Value = v
+		}
+
+		choices = append(choices, choice)
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{Choices: choices},
+	})
+	if err != nil {
+		log.Println("[discord] error sending suggestions", err)
+	}
+}
+
+// interactionMessage makes up the message a user would have sent for a slash
+// command
+func interactionMessage(i *discordgo.InteractionCreate, user *discordgo.User, text string) *discordgo.Message {
+	return &discordgo.Message{
+		ID:        i.ID,
+		ChannelID: i.ChannelID,
+		GuildID:   i.GuildID,
+		Author:    user,
+		Content:   text,
+		Timestamp: time.Now(),
+	}
+}
+
+func subcommand(spec *proto.CommandSpec, name string) *proto.CommandSpec {
+	if spec.Schema == nil {
+		return nil
+	}
+	for _, sub := range spec.Schema.Subcommands {
+		if sub.Name == name {
+			return sub
+		}
+	}
+	return nil
+}
+
+// optionValue returns the value of o as it would be typed
+func optionValue(o *discordgo.ApplicationCommandInteractionDataOption) string {
+	switch o.Type {
+	case discordgo.ApplicationCommandOptionUser:
+		return "<@" + fmt.Sprint(o.Value) + ">"
+	case discordgo.ApplicationCommandOptionChannel:
+		return "<#" + fmt.Sprint(o.Value) + ">"
+	}
+
+	switch v := o.Value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// quote double quotes s so it is a single argument
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,462 @@
+package slack
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/micro/go-bot/input"
+	"github.com/nlopes/slack"
+
+	"github.com/chremoas/chremoas/events"
+	proto "github.com/chremoas/chremoas/proto"
+	"github.com/chremoas/chremoas/render"
+)
+
+// Satisfies the input.Conn interface
+type slackConn struct {
+	auth *slack.AuthTestResponse
+	rtm  *slack.RTM
+	exit chan bool
+
+	sync.Mutex
+	names map[string]string
+}
+
+func (s *slackConn) run() {
+	// func retrieves user names and maps to IDs
+	setNames := func() {
+		names := make(map[string]string)
+		users, err := s.rtm.Client.GetUsers()
+		if err != nil {
+			return
+		}
+
+		for _, user := range users {
+			names[user.ID] = user.Name
+		}
+
+		s.Lock()
+		s.names = names
+		s.Unlock()
+	}
+
+	setNames()
+
+	t := time.NewTicker(time.Minute)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-s.exit:
+			return
+		case <-t.C:
+			setNames()
+		}
+	}
+}
+
+func (s *slackConn) getName(id string) string {
+	s.Lock()
+	name := s.names[id]
+	s.Unlock()
+	return name
+}
+
+func (s *slackConn) Close() error {
+	select {
+	case <-s.exit:
+		return nil
+	default:
+		close(s.exit)
+	}
+	return nil
+}
+
+func (s *slackConn) Recv(event *input.Event) error {
+	if event == nil {
+		return errors.New("event cannot be nil")
+	}
+
+	for {
+		select {
+		case <-s.exit:
+			return errors.New("connection closed")
+		case e := <-s.rtm.IncomingEvents:
+			switch ev := e.Data.(type) {
+			case *slack.MessageEvent:
+				// only accept type message
+				if ev.Type != "message" {
+					continue
+				}
+
+				// edits and deletes are chat events rather than commands
+				switch ev.SubType {
+				case "message_changed":
+					if ev.SubMessage != nil && s.chatEvent(event, &proto.ChatEvent{
+						Type:      events.MessageEdit,
+						ChannelId: ev.Channel,
+						UserId:    ev.SubMessage.User,
+						MessageId: ev.SubMessage.Timestamp,
+						Text:      ev.SubMessage.Text,
+					}) {
+						return nil
+					}
+					continue
+				case "message_deleted":
+					s.chatEvent(event, &proto.ChatEvent{
+						Type:      events.MessageDelete,
+						ChannelId: ev.Channel,
+						MessageId: ev.DeletedTimestamp,
+					})
+					return nil
+				}
+
+				// only accept DMs or messages to me
+				switch {
+				case strings.HasPrefix(ev.Channel, "D"):
+				case strings.HasPrefix(ev.Text, s.auth.User):
+				case strings.HasPrefix(ev.Text, fmt.Sprintf("<@%s>", s.auth.UserID)):
+				default:
+					continue
+				}
+
+				// Strip username from text
+				var prefix string
+				switch {
+				case strings.HasPrefix(ev.Text, s.auth.User):
+					args := strings.Split(ev.Text, " ")
+					prefix = args[0] + " "
+					ev.Text = strings.Join(args[1:], " ")
+					event.To = s.auth.User
+				case strings.HasPrefix(ev.Text, fmt.Sprintf("<@%s>", s.auth.UserID)):
+					args := strings.Split(ev.Text, " ")
+					prefix = args[0] + " "
+					ev.Text = strings.Join(args[1:], " ")
+					event.To = s.auth.UserID
+				}
+
+				if event.Meta == nil {
+					event.Meta = make(map[string]interface{})
+				}
+				event.Meta["prefix"] = prefix
+
+				// fill in the blanks
+				event.From = ev.Channel + ":" + ev.User
+				event.Type = input.TextEvent
+				event.Data = []byte(ev.Text)
+				event.Meta["reply"] = ev
+				event.Meta[events.GuildKey] = ev.Team
+				event.Meta[events.ChannelKey] = ev.Channel
+				event.Meta[events.UserKey] = ev.User
+				event.Meta[events.MessageKey] = ev.Timestamp
+				event.Meta[events.ThreadKey] = ev.ThreadTimestamp
+				event.Meta[events.DirectKey] = strings.HasPrefix(ev.Channel, "D")
+				// messages only carry the user name for bots
+				if name := s.getName(ev.User); len(name) > 0 {
+					event.Meta[events.NameKey] = name
+				} else {
+					event.Meta[events.NameKey] = ev.Username
+				}
+				if ts, err := strconv.ParseFloat(ev.Timestamp, 64); err == nil {
+					event.Meta[events.TimeKey] = time.Unix(int64(ts), 0)
+				}
+				return nil
+			case *slack.MemberJoinedChannelEvent:
+				if s.chatEvent(event, &proto.ChatEvent{Type: events.MemberJoin, GuildId: ev.Team, ChannelId: ev.Channel, UserId: ev.User}) {
+					return nil
+				}
+			case *slack.MemberLeftChannelEvent:
+				if s.chatEvent(event, &proto.ChatEvent{Type: events.MemberLeave, GuildId: ev.Team, ChannelId: ev.Channel, UserId: ev.User}) {
+					return nil
+				}
+			case *slack.ReactionAddedEvent:
+				if s.chatEvent(event, &proto.ChatEvent{
+					Type:      events.ReactionAdd,
+					ChannelId: ev.Item.Channel,
+					UserId:    ev.User,
+					MessageId: ev.Item.Timestamp,
+					Emoji:     ev.Reaction,
+				}) {
+					return nil
+				}
+			case *slack.ReactionRemovedEvent:
+				if s.chatEvent(event, &proto.ChatEvent{
+					Type:      events.ReactionRemove,
+					ChannelId: ev.Item.Channel,
+					UserId:    ev.User,
+					MessageId: ev.Item.Timestamp,
+					Emoji:     ev.Reaction,
+				}) {
+					return nil
+				}
+			case *slack.PresenceChangeEvent:
+				if s.chatEvent(event, &proto.ChatEvent{Type: events.Presence, UserId: ev.User, Status: ev.Presence}) {
+					return nil
+				}
+			case *slack.InvalidAuthEvent:
+				return errors.New("invalid credentials")
+			}
+		}
+	}
+}
+
+// chatEvent fills in event for a chat event other than a command, it
+// reports false for events caused by the bot itself
+func (s *slackConn) chatEvent(event *input.Event, ce *proto.ChatEvent) bool {
+	if len(ce.UserId) > 0 && ce.UserId == s.auth.UserID {
+		return false
+	}
+
+	event.From = ce.ChannelId + ":" + ce.UserId
+	event.To = s.auth.UserID
+	event.Type = input.EventType(ce.Type)
+	event.Data = nil
+	event.Meta = map[string]interface{}{
+		events.Key: ce,
+	}
+
+	return true
+}
+
+// destination works out the channel event is for and the name of the user
+// being replied to
+func (s *slackConn) destination(event *input.Event) (string, string, error) {
+	var channel, name string
+
+	if len(event.To) == 0 {
+		return "", "", errors.New("require Event.To")
+	}
+
+	parts := strings.Split(event.To, ":")
+
+	if len(parts) == 2 {
+		channel = parts[0]
+		name = s.getName(parts[1])
+		// try using reply meta
+	} else if ev, ok := event.Meta["reply"]; ok {
+		channel = ev.(*slack.MessageEvent).Channel
+		name = s.getName(ev.(*slack.MessageEvent).User)
+	}
+
+	// don't know where to send the message
+	if len(channel) == 0 {
+		return "", "", errors.New("could not determine who message is to")
+	}
+
+	return channel, name, nil
+}
+
+// message works out the channel event is for and the text to send there
+func (s *slackConn) message(event *input.Event) (string, string, error) {
+	channel, name, err := s.destination(event)
+	if err != nil {
+		return "", "", err
+	}
+
+	if len(name) == 0 || strings.HasPrefix(channel, "D") {
+		return channel, string(event.Data), nil
+	}
+
+	return channel, fmt.Sprintf("@%s: %s", name, string(event.Data)), nil
+}
+
+func (s *slackConn) Send(event *input.Event) error {
+	channel, message, err := s.message(event)
+	if err != nil {
+		return err
+	}
+
+	s.rtm.SendMessage(s.rtm.NewOutgoingMessage(message, channel))
+	return nil
+}
+
+// SendEditable sends event and returns the timestamp of the message so it
+// can be edited
+func (s *slackConn) SendEditable(event *input.Event) (string, error) {
+	channel, message, err := s.message(event)
+	if err != nil {
+		return "", err
+	}
+
+	_, ts, err := s.rtm.PostMessage(channel,
+		slack.MsgOptionAsUser(true),
+		slack.MsgOptionText(message, false),
+	)
+	return ts, err
+}
+
+// Edit replaces the text of the message sent at timestamp id with event
+func (s *slackConn) Edit(event *input.Event, id string) error {
+	channel, message, err := s.message(event)
+	if err != nil {
+		return err
+	}
+
+	_, _, _, err = s.rtm.UpdateMessage(channel, id,
+		slack.MsgOptionAsUser(true),
+		slack.MsgOptionText(message, false),
+	)
+	return err
+}
+
+// DirectChannel returns the channel for direct messages with user
+func (s *slackConn) DirectChannel(user string) (string, error) {
+	_, _, channel, err := s.rtm.OpenIMChannel(user)
+	return channel, err
+}
+
+// SendFile uploads data as a file named name
+func (s *slackConn) SendFile(event *input.Event, name string, data []byte) error {
+	channel, _, err := s.destination(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.rtm.UploadFile(slack.FileUploadParameters{
+		Reader:   bytes.NewReader(data),
+		Filename: name,
+		Channels: []string{channel},
+	})
+	return err
+}
+
+// SendRich sends a rich response as message blocks
+func (s *slackConn) SendRich(event *input.Event, rich *proto.RichResponse) error {
+	channel, _, err := s.destination(event)
+	if err != nil {
+		return err
+	}
+
+	var blocks []slack.Block
+
+	if len(rich.Title) > 0 {
+		blocks = append(blocks, section("*"+truncate(rich.Title, maxSectionText-2)+"*"))
+	}
+
+	for _, text := range rich.Text {
+		for _, piece := range split(text, maxSectionText) {
+			blocks = append(blocks, section(piece))
+		}
+	}
+
+	// sections hold a limited number of fields, the rest go in more of them
+	var fields []*slack.TextBlockObject
+	for i, f := range rich.Fields {
+		text := truncate(fmt.Sprintf("*%s*\n%s", f.Name, f.Value), maxFieldText)
+		fields = append(fields, slack.NewTextBlockObject(slack.MarkdownType, text, false, false))
+
+		if len(fields) == maxFields || i == len(rich.Fields)-1 {
+			blocks = append(blocks, slack.NewSectionBlock(nil, fields, nil))
+			fields = nil
+		}
+	}
+
+	for _, t := range rich.Tables {
+		for _, piece := range split(render.Table(t), maxSectionText-6) {
+			blocks = append(blocks, section("```"+piece+"```"))
+		}
+	}
+
+	if len(rich.ImageUrl) > 0 {
+		blocks = append(blocks, slack.NewImageBlock(rich.ImageUrl, truncate(rich.Title, maxAltText), "", nil))
+	}
+
+	if len(rich.Footer) > 0 {
+		blocks = append(blocks, slack.NewContextBlock("", slack.NewTextBlockObject(slack.MarkdownType, truncate(rich.Footer, maxSectionText), false, false)))
+	}
+
+	if len(blocks) > maxBlocks {
+		return errTooLarge
+	}
+
+	// components alone are left to the bot to offer as numbered choices
+	sent := false
+	if len(blocks) > 0 {
+		_, _, err = s.rtm.PostMessage(channel,
+			slack.MsgOptionAsUser(true),
+			slack.MsgOptionText(render.Text(rich), false),
+			slack.MsgOptionBlocks(blocks...),
+		)
+		if err != nil {
+			return err
+		}
+		sent = true
+	}
+
+	// the bot would send what was sent already again as text if it saw
+	// the error
+	for _, a := range rich.Attachments {
+		if err := s.SendFile(event, a.Name, a.Data); err != nil {
+			if sent {
+				return &partialError{err}
+			}
+			return err
+		}
+		sent = true
+	}
+
+	return nil
+}
+
+func section(text string) *slack.SectionBlock {
+	return slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, text, false, false), nil, nil)
+}
+
+// Limits slack puts on message blocks, in characters
+const (
+	maxBlocks      = 50
+	maxSectionText = 3000
+	maxFields      = 10
+	maxFieldText   = 2000
+	maxAltText     = 2000
+)
+
+// errTooLarge is returned for rich responses that need more blocks than a
+// message can have, the bot sends them as text instead
+var errTooLarge = errors.New("rich response has too many blocks")
+
+// partialError is returned when part of a rich response was already sent
+type partialError struct {
+	err error
+}
+
+func (e *partialError) Error() string {
+	return e.err.Error()
+}
+
+// Partial tells the bot not to send the response again as text
+func (e *partialError) Partial() bool {
+	return true
+}
+
+// split breaks text into pieces of at most limit characters, preferring to
+// break at the end of a line
+func split(text string, limit int) []string {
+	var pieces []string
+
+	for utf8.RuneCountInString(text) > limit {
+		runes := []rune(text)
+		cut := strings.LastIndex(string(runes[:limit]), "\n") + 1
+		if cut == 0 {
+			cut = len(string(runes[:limit]))
+		}
+		pieces = append(pieces, text[:cut])
+		text = text[cut:]
+	}
+
+	return append(pieces, text)
+}
+
+// truncate cuts s short to at most n characters
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n-1]) + "…"
+}
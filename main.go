@@ -4,15 +4,15 @@ import (
 	"fmt"
 
 	"github.com/micro/go-bot/input"
-	_ "github.com/micro/go-bot/input/discord"
 	_ "github.com/micro/go-bot/input/hipchat"
-	_ "github.com/micro/go-bot/input/slack"
 	"github.com/micro/go-micro/config/cmd"
 	"go.uber.org/zap"
 
 	chremoasPrometheus "github.com/chremoas/services-common/prometheus"
 
 	"github.com/chremoas/chremoas/bot"
+	_ "github.com/chremoas/chremoas/input/discord"
+	_ "github.com/chremoas/chremoas/input/slack"
 )
 
 var (
@@ -10,8 +10,31 @@ It is generated from these files:
 It has these top-level messages:
 	HelpRequest
 	HelpResponse
+	CommandSchema
+	CommandSpec
+	Argument
+	Flag
+	CompleteRequest
+	CompleteResponse
+	Suggestion
 	ExecRequest
 	ExecResponse
+	FollowUp
+	ExecStreamResponse
+	ChatEvent
+	ChatEventResponse
+	RichResponse
+	Field
+	Table
+	Row
+	Attachment
+	Component
+	SelectOption
+	InteractRequest
+	JobUpdate
+	JobUpdateResponse
+	Notification
+	NotificationResponse
 */
 package go_micro_bot
 
@@ -116,3 +139,422 @@ func (h *commandHandler) Help(ctx context.Context, in *HelpRequest, out *HelpRes
 func (h *commandHandler) Exec(ctx context.Context, in *ExecRequest, out *ExecResponse) error {
 	return h.CommandHandler.Exec(ctx, in, out)
 }
+
+// Client API for CommandStream service
+
+type CommandStreamService interface {
+	ExecStream(ctx context.Context, in *ExecRequest, opts ...client.CallOption) (CommandStream_ExecStreamService, error)
+}
+
+type commandStreamService struct {
+	c    client.Client
+	name string
+}
+
+func NewCommandStreamService(name string, c client.Client) CommandStreamService {
+	if c == nil {
+		c = client.NewClient()
+	}
+	if len(name) == 0 {
+		name = "go.micro.bot"
+	}
+	return &commandStreamService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *commandStreamService) ExecStream(ctx context.Context, in *ExecRequest, opts ...client.CallOption) (CommandStream_ExecStreamService, error) {
+	req := c.c.NewRequest(c.name, "CommandStream.ExecStream", &ExecRequest{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &commandStreamServiceExecStream{stream}, nil
+}
+
+type CommandStream_ExecStreamService interface {
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Recv() (*ExecStreamResponse, error)
+}
+
+type commandStreamServiceExecStream struct {
+	stream client.Stream
+}
+
+func (x *commandStreamServiceExecStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *commandStreamServiceExecStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *commandStreamServiceExecStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *commandStreamServiceExecStream) Recv() (*ExecStreamResponse, error) {
+	m := new(ExecStreamResponse)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for CommandStream service
+
+type CommandStreamHandler interface {
+	ExecStream(context.Context, *ExecRequest, CommandStream_ExecStreamStream) error
+}
+
+func RegisterCommandStreamHandler(s server.Server, hdlr CommandStreamHandler, opts ...server.HandlerOption) {
+	type commandStream interface {
+		ExecStream(ctx context.Context, stream server.Stream) error
+	}
+	type CommandStream struct {
+		commandStream
+	}
+	h := &commandStreamHandler{hdlr}
+	s.Handle(s.NewHandler(&CommandStream{h}, opts...))
+}
+
+type commandStreamHandler struct {
+	CommandStreamHandler
+}
+
+func (h *commandStreamHandler) ExecStream(ctx context.Context, stream server.Stream) error {
+	m := new(ExecRequest)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.CommandStreamHandler.ExecStream(ctx, m, &commandStreamExecStreamStream{stream})
+}
+
+type CommandStream_ExecStreamStream interface {
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*ExecStreamResponse) error
+}
+
+type commandStreamExecStreamStream struct {
+	stream server.Stream
+}
+
+func (x *commandStreamExecStreamStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *commandStreamExecStreamStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *commandStreamExecStreamStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *commandStreamExecStreamStream) Send(m *ExecStreamResponse) error {
+	return x.stream.Send(m)
+}
+
+// Client API for Completion service
+
+type CompletionService interface {
+	Complete(ctx context.Context, in *CompleteRequest, opts ...client.CallOption) (*CompleteResponse, error)
+}
+
+type completionService struct {
+	c    client.Client
+	name string
+}
+
+func NewCompletionService(name string, c client.Client) CompletionService {
+	if c == nil {
+		c = client.NewClient()
+	}
+	if len(name) == 0 {
+		name = "go.micro.bot"
+	}
+	return &completionService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *completionService) Complete(ctx context.Context, in *CompleteRequest, opts ...client.CallOption) (*CompleteResponse, error) {
+	req := c.c.NewRequest(c.name, "Completion.Complete", in)
+	out := new(CompleteResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Completion service
+
+type CompletionHandler interface {
+	Complete(context.Context, *CompleteRequest, *CompleteResponse) error
+}
+
+func RegisterCompletionHandler(s server.Server, hdlr CompletionHandler, opts ...server.HandlerOption) {
+	type completion interface {
+		Complete(ctx context.Context, in *CompleteRequest, out *CompleteResponse) error
+	}
+	type Completion struct {
+		completion
+	}
+	h := &completionHandler{hdlr}
+	s.Handle(s.NewHandler(&Completion{h}, opts...))
+}
+
+type completionHandler struct {
+	CompletionHandler
+}
+
+func (h *completionHandler) Complete(ctx context.Context, in *CompleteRequest, out *CompleteResponse) error {
+	return h.CompletionHandler.Complete(ctx, in, out)
+}
+
+// Client API for ChatEvents service
+
+type ChatEventsService interface {
+	Event(ctx context.Context, in *ChatEvent, opts ...client.CallOption) (*ChatEventResponse, error)
+}
+
+type chatEventsService struct {
+	c    client.Client
+	name string
+}
+
+func NewChatEventsService(name string, c client.Client) ChatEventsService {
+	if c == nil {
+		c = client.NewClient()
+	}
+	if len(name) == 0 {
+		name = "go.micro.bot"
+	}
+	return &chatEventsService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *chatEventsService) Event(ctx context.Context, in *ChatEvent, opts ...client.CallOption) (*ChatEventResponse, error) {
+	req := c.c.NewRequest(c.name, "ChatEvents.Event", in)
+	out := new(ChatEventResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for ChatEvents service
+
+type ChatEventsHandler interface {
+	Event(context.Context, *ChatEvent, *ChatEventResponse) error
+}
+
+func RegisterChatEventsHandler(s server.Server, hdlr ChatEventsHandler, opts ...server.HandlerOption) {
+	type chatEvents interface {
+		Event(ctx context.Context, in *ChatEvent, out *ChatEventResponse) error
+	}
+	type ChatEvents struct {
+		chatEvents
+	}
+	h := &chatEventsHandler{hdlr}
+	s.Handle(s.NewHandler(&ChatEvents{h}, opts...))
+}
+
+type chatEventsHandler struct {
+	ChatEventsHandler
+}
+
+func (h *chatEventsHandler) Event(ctx context.Context, in *ChatEvent, out *ChatEventResponse) error {
+	return h.ChatEventsHandler.Event(ctx, in, out)
+}
+
+// Client API for Interaction service
+
+type InteractionService interface {
+	Interact(ctx context.Context, in *InteractRequest, opts ...client.CallOption) (*ExecResponse, error)
+}
+
+type interactionService struct {
+	c    client.Client
+	name string
+}
+
+func NewInteractionService(name string, c client.Client) InteractionService {
+	if c == nil {
+		c = client.NewClient()
+	}
+	if len(name) == 0 {
+		name = "go.micro.bot"
+	}
+	return &interactionService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *interactionService) Interact(ctx context.Context, in *InteractRequest, opts ...client.CallOption) (*ExecResponse, error) {
+	req := c.c.NewRequest(c.name, "Interaction.Interact", in)
+	out := new(ExecResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Interaction service
+
+type InteractionHandler interface {
+	Interact(context.Context, *InteractRequest, *ExecResponse) error
+}
+
+func RegisterInteractionHandler(s server.Server, hdlr InteractionHandler, opts ...server.HandlerOption) {
+	type interaction interface {
+		Interact(ctx context.Context, in *InteractRequest, out *ExecResponse) error
+	}
+	type Interaction struct {
+		interaction
+	}
+	h := &interactionHandler{hdlr}
+	s.Handle(s.NewHandler(&Interaction{h}, opts...))
+}
+
+type interactionHandler struct {
+	InteractionHandler
+}
+
+func (h *interactionHandler) Interact(ctx context.Context, in *InteractRequest, out *ExecResponse) error {
+	return h.InteractionHandler.Interact(ctx, in, out)
+}
+
+// Client API for Jobs service
+
+type JobsService interface {
+	Update(ctx context.Context, in *JobUpdate, opts ...client.CallOption) (*JobUpdateResponse, error)
+}
+
+type jobsService struct {
+	c    client.Client
+	name string
+}
+
+func NewJobsService(name string, c client.Client) JobsService {
+	if c == nil {
+		c = client.NewClient()
+	}
+	if len(name) == 0 {
+		name = "go.micro.bot"
+	}
+	return &jobsService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *jobsService) Update(ctx context.Context, in *JobUpdate, opts ...client.CallOption) (*JobUpdateResponse, error) {
+	req := c.c.NewRequest(c.name, "Jobs.Update", in)
+	out := new(JobUpdateResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Jobs service
+
+type JobsHandler interface {
+	Update(context.Context, *JobUpdate, *JobUpdateResponse) error
+}
+
+func RegisterJobsHandler(s server.Server, hdlr JobsHandler, opts ...server.HandlerOption) {
+	type jobs interface {
+		Update(ctx context.Context, in *JobUpdate, out *JobUpdateResponse) error
+	}
+	type Jobs struct {
+		jobs
+	}
+	h := &jobsHandler{hdlr}
+	s.Handle(s.NewHandler(&Jobs{h}, opts...))
+}
+
+type jobsHandler struct {
+	JobsHandler
+}
+
+func (h *jobsHandler) Update(ctx context.Context, in *JobUpdate, out *JobUpdateResponse) error {
+	return h.JobsHandler.Update(ctx, in, out)
+}
+
+// Client API for Notify service
+
+type NotifyService interface {
+	Send(ctx context.Context, in *Notification, opts ...client.CallOption) (*NotificationResponse, error)
+}
+
+type notifyService struct {
+	c    client.Client
+	name string
+}
+
+func NewNotifyService(name string, c client.Client) NotifyService {
+	if c == nil {
+		c = client.NewClient()
+	}
+	if len(name) == 0 {
+		name = "go.micro.bot"
+	}
+	return &notifyService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *notifyService) Send(ctx context.Context, in *Notification, opts ...client.CallOption) (*NotificationResponse, error) {
+	req := c.c.NewRequest(c.name, "Notify.Send", in)
+	out := new(NotificationResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Notify service
+
+type NotifyHandler interface {
+	Send(context.Context, *Notification, *NotificationResponse) error
+}
+
+func RegisterNotifyHandler(s server.Server, hdlr NotifyHandler, opts ...server.HandlerOption) {
+	type notify interface {
+		Send(ctx context.Context, in *Notification, out *NotificationResponse) error
+	}
+	type Notify struct {
+		notify
+	}
+	h := &notifyHandler{hdlr}
+	s.Handle(s.NewHandler(&Notify{h}, opts...))
+}
+
+type notifyHandler struct {
+	NotifyHandler
+}
+
+func (h *notifyHandler) Send(ctx context.Context, in *Notification, out *NotificationResponse) error {
+	return h.NotifyHandler.Send(ctx, in, out)
+}
@@ -1,23 +1,13 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // source: bot.proto
 
-/*
-Package go_micro_bot is a generated protocol buffer package.
-
-It is generated from these files:
-	bot.proto
-
-It has these top-level messages:
-	HelpRequest
-	HelpResponse
-	ExecRequest
-	ExecResponse
-*/
 package go_micro_bot
 
-import proto "github.com/golang/protobuf/proto"
-import fmt "fmt"
-import math "math"
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -28,112 +18,2139 @@ var _ = math.Inf
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type ArgumentType int32
+
+const (
+	ArgumentType_STRING ArgumentType = 0
+	ArgumentType_INT    ArgumentType = 1
+	ArgumentType_NUMBER ArgumentType = 2
+	ArgumentType_BOOL   ArgumentType = 3
+	// a duration like 1h30m
+	ArgumentType_DURATION ArgumentType = 4
+	ArgumentType_USER     ArgumentType = 5
+	ArgumentType_CHANNEL  ArgumentType = 6
+)
+
+var ArgumentType_name = map[int32]string{
+	0: "STRING",
+	1: "INT",
+	2: "NUMBER",
+	3: "BOOL",
+	4: "DURATION",
+	5: "USER",
+	6: "CHANNEL",
+}
+
+var ArgumentType_value = map[string]int32{
+	"STRING":   0,
+	"INT":      1,
+	"NUMBER":   2,
+	"BOOL":     3,
+	"DURATION": 4,
+	"USER":     5,
+	"CHANNEL":  6,
+}
+
+func (x ArgumentType) String() string {
+	return proto.EnumName(ArgumentType_name, int32(x))
+}
+
+func (ArgumentType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{0}
+}
+
+type ComponentType int32
+
+const (
+	ComponentType_BUTTON ComponentType = 0
+	ComponentType_SELECT ComponentType = 1
+)
+
+var ComponentType_name = map[int32]string{
+	0: "BUTTON",
+	1: "SELECT",
+}
+
+var ComponentType_value = map[string]int32{
+	"BUTTON": 0,
+	"SELECT": 1,
+}
+
+func (x ComponentType) String() string {
+	return proto.EnumName(ComponentType_name, int32(x))
+}
+
+func (ComponentType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{1}
+}
+
+type ButtonStyle int32
+
+const (
+	ButtonStyle_PRIMARY   ButtonStyle = 0
+	ButtonStyle_SECONDARY ButtonStyle = 1
+	ButtonStyle_SUCCESS   ButtonStyle = 2
+	ButtonStyle_DANGER    ButtonStyle = 3
+)
+
+var ButtonStyle_name = map[int32]string{
+	0: "PRIMARY",
+	1: "SECONDARY",
+	2: "SUCCESS",
+	3: "DANGER",
+}
+
+var ButtonStyle_value = map[string]int32{
+	"PRIMARY":   0,
+	"SECONDARY": 1,
+	"SUCCESS":   2,
+	"DANGER":    3,
+}
+
+func (x ButtonStyle) String() string {
+	return proto.EnumName(ButtonStyle_name, int32(x))
+}
+
+func (ButtonStyle) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{2}
+}
 
 type HelpRequest struct {
+	// the protocol version of the bot and the capabilities it supports
+	ProtocolVersion      int32    `protobuf:"varint,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Capabilities         []string `protobuf:"bytes,2,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HelpRequest) Reset()         { *m = HelpRequest{} }
+func (m *HelpRequest) String() string { return proto.CompactTextString(m) }
+func (*HelpRequest) ProtoMessage()    {}
+func (*HelpRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{0}
+}
+
+func (m *HelpRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HelpRequest.Unmarshal(m, b)
+}
+func (m *HelpRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HelpRequest.Marshal(b, m, deterministic)
+}
+func (m *HelpRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HelpRequest.Merge(m, src)
+}
+func (m *HelpRequest) XXX_Size() int {
+	return xxx_messageInfo_HelpRequest.Size(m)
+}
+func (m *HelpRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HelpRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HelpRequest proto.InternalMessageInfo
+
+func (m *HelpRequest) GetProtocolVersion() int32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *HelpRequest) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+type HelpResponse struct {
+	Usage       string `protobuf:"bytes,1,opt,name=usage,proto3" json:"usage,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// how long the command usually takes to run, in seconds
+	ExpectedRuntime int64 `protobuf:"varint,3,opt,name=expected_runtime,json=expectedRuntime,proto3" json:"expected_runtime,omitempty"`
+	// how long the bot waits for the command before giving up, in seconds
+	MaxRuntime int64 `protobuf:"varint,4,opt,name=max_runtime,json=maxRuntime,proto3" json:"max_runtime,omitempty"`
+	// describes the arguments of the command, the bot checks them before
+	// the command is run and offers the command as a slash command on
+	// inputs that have them
+	Schema *CommandSchema `protobuf:"bytes,5,opt,name=schema,proto3" json:"schema,omitempty"`
+	// the protocol version of the service, services that leave it at 0 are
+	// from before versions and only get Help and Exec called
+	ProtocolVersion int32 `protobuf:"varint,6,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	// the capabilities the service supports, see protocol.go
+	Capabilities         []string `protobuf:"bytes,7,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HelpResponse) Reset()         { *m = HelpResponse{} }
+func (m *HelpResponse) String() string { return proto.CompactTextString(m) }
+func (*HelpResponse) ProtoMessage()    {}
+func (*HelpResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{1}
+}
+
+func (m *HelpResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HelpResponse.Unmarshal(m, b)
+}
+func (m *HelpResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HelpResponse.Marshal(b, m, deterministic)
+}
+func (m *HelpResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HelpResponse.Merge(m, src)
+}
+func (m *HelpResponse) XXX_Size() int {
+	return xxx_messageInfo_HelpResponse.Size(m)
+}
+func (m *HelpResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HelpResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HelpResponse proto.InternalMessageInfo
+
+func (m *HelpResponse) GetUsage() string {
+	if m != nil {
+		return m.Usage
+	}
+	return ""
+}
+
+func (m *HelpResponse) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *HelpResponse) GetExpectedRuntime() int64 {
+	if m != nil {
+		return m.ExpectedRuntime
+	}
+	return 0
+}
+
+func (m *HelpResponse) GetMaxRuntime() int64 {
+	if m != nil {
+		return m.MaxRuntime
+	}
+	return 0
+}
+
+func (m *HelpResponse) GetSchema() *CommandSchema {
+	if m != nil {
+		return m.Schema
+	}
+	return nil
+}
+
+func (m *HelpResponse) GetProtocolVersion() int32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *HelpResponse) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+type CommandSchema struct {
+	Subcommands []*CommandSpec `protobuf:"bytes,1,rep,name=subcommands,proto3" json:"subcommands,omitempty"`
+	Arguments   []*Argument    `protobuf:"bytes,2,rep,name=arguments,proto3" json:"arguments,omitempty"`
+	Flags       []*Flag        `protobuf:"bytes,3,rep,name=flags,proto3" json:"flags,omitempty"`
+	// full command lines showing how the command is used
+	Examples []string `protobuf:"bytes,4,rep,name=examples,proto3" json:"examples,omitempty"`
+	// who may run the command e.g. "admins", it is only shown in help
+	Permission           string   `protobuf:"bytes,5,opt,name=permission,proto3" json:"permission,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandSchema) Reset()         { *m = CommandSchema{} }
+func (m *CommandSchema) String() string { return proto.CompactTextString(m) }
+func (*CommandSchema) ProtoMessage()    {}
+func (*CommandSchema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{2}
+}
+
+func (m *CommandSchema) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandSchema.Unmarshal(m, b)
+}
+func (m *CommandSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandSchema.Marshal(b, m, deterministic)
+}
+func (m *CommandSchema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandSchema.Merge(m, src)
+}
+func (m *CommandSchema) XXX_Size() int {
+	return xxx_messageInfo_CommandSchema.Size(m)
+}
+func (m *CommandSchema) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandSchema.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandSchema proto.InternalMessageInfo
+
+func (m *CommandSchema) GetSubcommands() []*CommandSpec {
+	if m != nil {
+		return m.Subcommands
+	}
+	return nil
+}
+
+func (m *CommandSchema) GetArguments() []*Argument {
+	if m != nil {
+		return m.Arguments
+	}
+	return nil
+}
+
+func (m *CommandSchema) GetFlags() []*Flag {
+	if m != nil {
+		return m.Flags
+	}
+	return nil
+}
+
+func (m *CommandSchema) GetExamples() []string {
+	if m != nil {
+		return m.Examples
+	}
+	return nil
+}
+
+func (m *CommandSchema) GetPermission() string {
+	if m != nil {
+		return m.Permission
+	}
+	return ""
+}
+
+// CommandSpec describes a subcommand, or a command when the bot passes the
+// commands it knows to an input
+type CommandSpec struct {
+	Name        string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Usage       string         `protobuf:"bytes,2,opt,name=usage,proto3" json:"usage,omitempty"`
+	Description string         `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Schema      *CommandSchema `protobuf:"bytes,4,opt,name=schema,proto3" json:"schema,omitempty"`
+	// hidden subcommands run but aren't listed in help or offered natively
+	Hidden               bool     `protobuf:"varint,5,opt,name=hidden,proto3" json:"hidden,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandSpec) Reset()         { *m = CommandSpec{} }
+func (m *CommandSpec) String() string { return proto.CompactTextString(m) }
+func (*CommandSpec) ProtoMessage()    {}
+func (*CommandSpec) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{3}
+}
+
+func (m *CommandSpec) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandSpec.Unmarshal(m, b)
+}
+func (m *CommandSpec) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandSpec.Marshal(b, m, deterministic)
+}
+func (m *CommandSpec) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandSpec.Merge(m, src)
+}
+func (m *CommandSpec) XXX_Size() int {
+	return xxx_messageInfo_CommandSpec.Size(m)
+}
+func (m *CommandSpec) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandSpec.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandSpec proto.InternalMessageInfo
+
+func (m *CommandSpec) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CommandSpec) GetUsage() string {
+	if m != nil {
+		return m.Usage
+	}
+	return ""
+}
+
+func (m *CommandSpec) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *CommandSpec) GetSchema() *CommandSchema {
+	if m != nil {
+		return m.Schema
+	}
+	return nil
+}
+
+func (m *CommandSpec) GetHidden() bool {
+	if m != nil {
+		return m.Hidden
+	}
+	return false
+}
+
+type Argument struct {
+	Name         string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description  string       `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Type         ArgumentType `protobuf:"varint,3,opt,name=type,proto3,enum=go.micro.bot.ArgumentType" json:"type,omitempty"`
+	Required     bool         `protobuf:"varint,4,opt,name=required,proto3" json:"required,omitempty"`
+	DefaultValue string       `protobuf:"bytes,5,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	// the only values allowed, any value is allowed if empty
+	Choices []string `protobuf:"bytes,6,rep,name=choices,proto3" json:"choices,omitempty"`
+	// the argument takes the rest of the arguments, it has to be the last
+	Variadic bool `protobuf:"varint,7,opt,name=variadic,proto3" json:"variadic,omitempty"`
+	// values can be suggested through Complete
+	Complete             bool     `protobuf:"varint,8,opt,name=complete,proto3" json:"complete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Argument) Reset()         { *m = Argument{} }
+func (m *Argument) String() string { return proto.CompactTextString(m) }
+func (*Argument) ProtoMessage()    {}
+func (*Argument) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{4}
+}
+
+func (m *Argument) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Argument.Unmarshal(m, b)
+}
+func (m *Argument) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Argument.Marshal(b, m, deterministic)
+}
+func (m *Argument) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Argument.Merge(m, src)
+}
+func (m *Argument) XXX_Size() int {
+	return xxx_messageInfo_Argument.Size(m)
+}
+func (m *Argument) XXX_DiscardUnknown() {
+	xxx_messageInfo_Argument.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Argument proto.InternalMessageInfo
+
+func (m *Argument) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Argument) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Argument) GetType() ArgumentType {
+	if m != nil {
+		return m.Type
+	}
+	return ArgumentType_STRING
+}
+
+func (m *Argument) GetRequired() bool {
+	if m != nil {
+		return m.Required
+	}
+	return false
+}
+
+func (m *Argument) GetDefaultValue() string {
+	if m != nil {
+		return m.DefaultValue
+	}
+	return ""
+}
+
+func (m *Argument) GetChoices() []string {
+	if m != nil {
+		return m.Choices
+	}
+	return nil
+}
+
+func (m *Argument) GetVariadic() bool {
+	if m != nil {
+		return m.Variadic
+	}
+	return false
+}
+
+func (m *Argument) GetComplete() bool {
+	if m != nil {
+		return m.Complete
+	}
+	return false
+}
+
+// Flag is an option given as --name value, or just --name for bool flags
+type Flag struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// single letter form given as -s
+	Short        string       `protobuf:"bytes,2,opt,name=short,proto3" json:"short,omitempty"`
+	Description  string       `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Type         ArgumentType `protobuf:"varint,4,opt,name=type,proto3,enum=go.micro.bot.ArgumentType" json:"type,omitempty"`
+	Required     bool         `protobuf:"varint,5,opt,name=required,proto3" json:"required,omitempty"`
+	DefaultValue string       `protobuf:"bytes,6,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	Choices      []string     `protobuf:"bytes,7,rep,name=choices,proto3" json:"choices,omitempty"`
+	// values can be suggested through Complete
+	Complete             bool     `protobuf:"varint,8,opt,name=complete,proto3" json:"complete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Flag) Reset()         { *m = Flag{} }
+func (m *Flag) String() string { return proto.CompactTextString(m) }
+func (*Flag) ProtoMessage()    {}
+func (*Flag) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{5}
+}
+
+func (m *Flag) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Flag.Unmarshal(m, b)
+}
+func (m *Flag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Flag.Marshal(b, m, deterministic)
+}
+func (m *Flag) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Flag.Merge(m, src)
+}
+func (m *Flag) XXX_Size() int {
+	return xxx_messageInfo_Flag.Size(m)
+}
+func (m *Flag) XXX_DiscardUnknown() {
+	xxx_messageInfo_Flag.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Flag proto.InternalMessageInfo
+
+func (m *Flag) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Flag) GetShort() string {
+	if m != nil {
+		return m.Short
+	}
+	return ""
+}
+
+func (m *Flag) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Flag) GetType() ArgumentType {
+	if m != nil {
+		return m.Type
+	}
+	return ArgumentType_STRING
+}
+
+func (m *Flag) GetRequired() bool {
+	if m != nil {
+		return m.Required
+	}
+	return false
+}
+
+func (m *Flag) GetDefaultValue() string {
+	if m != nil {
+		return m.DefaultValue
+	}
+	return ""
+}
+
+func (m *Flag) GetChoices() []string {
+	if m != nil {
+		return m.Choices
+	}
+	return nil
+}
+
+func (m *Flag) GetComplete() bool {
+	if m != nil {
+		return m.Complete
+	}
+	return false
+}
+
+type CompleteRequest struct {
+	// the command typed so far without the value being completed
+	Request *ExecRequest `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	// the argument or flag being completed, empty if it isn't known
+	Argument string `protobuf:"bytes,2,opt,name=argument,proto3" json:"argument,omitempty"`
+	// what was typed of the value so far
+	Prefix               string   `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompleteRequest) Reset()         { *m = CompleteRequest{} }
+func (m *CompleteRequest) String() string { return proto.CompactTextString(m) }
+func (*CompleteRequest) ProtoMessage()    {}
+func (*CompleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{6}
+}
+
+func (m *CompleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompleteRequest.Unmarshal(m, b)
+}
+func (m *CompleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompleteRequest.Marshal(b, m, deterministic)
+}
+func (m *CompleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompleteRequest.Merge(m, src)
+}
+func (m *CompleteRequest) XXX_Size() int {
+	return xxx_messageInfo_CompleteRequest.Size(m)
+}
+func (m *CompleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompleteRequest proto.InternalMessageInfo
+
+func (m *CompleteRequest) GetRequest() *ExecRequest {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *CompleteRequest) GetArgument() string {
+	if m != nil {
+		return m.Argument
+	}
+	return ""
+}
+
+func (m *CompleteRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+type CompleteResponse struct {
+	// best first
+	Suggestions          []*Suggestion `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *CompleteResponse) Reset()         { *m = CompleteResponse{} }
+func (m *CompleteResponse) String() string { return proto.CompactTextString(m) }
+func (*CompleteResponse) ProtoMessage()    {}
+func (*CompleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{7}
+}
+
+func (m *CompleteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompleteResponse.Unmarshal(m, b)
+}
+func (m *CompleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompleteResponse.Marshal(b, m, deterministic)
+}
+func (m *CompleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompleteResponse.Merge(m, src)
+}
+func (m *CompleteResponse) XXX_Size() int {
+	return xxx_messageInfo_CompleteResponse.Size(m)
+}
+func (m *CompleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompleteResponse proto.InternalMessageInfo
+
+func (m *CompleteResponse) GetSuggestions() []*Suggestion {
+	if m != nil {
+		return m.Suggestions
+	}
+	return nil
+}
+
+type Suggestion struct {
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// shown instead of value when set
+	Label                string   `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Suggestion) Reset()         { *m = Suggestion{} }
+func (m *Suggestion) String() string { return proto.CompactTextString(m) }
+func (*Suggestion) ProtoMessage()    {}
+func (*Suggestion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{8}
+}
+
+func (m *Suggestion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Suggestion.Unmarshal(m, b)
+}
+func (m *Suggestion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Suggestion.Marshal(b, m, deterministic)
+}
+func (m *Suggestion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Suggestion.Merge(m, src)
+}
+func (m *Suggestion) XXX_Size() int {
+	return xxx_messageInfo_Suggestion.Size(m)
+}
+func (m *Suggestion) XXX_DiscardUnknown() {
+	xxx_messageInfo_Suggestion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Suggestion proto.InternalMessageInfo
+
+func (m *Suggestion) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *Suggestion) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+type ExecRequest struct {
+	Sender string   `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
+	Args   []string `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
+	// the unparsed text of the command as typed by the user
+	Text string `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	// the output of the previous command when the command is piped into,
+	// it is also passed as the last argument to services without a schema
+	Stdin []byte `protobuf:"bytes,4,opt,name=stdin,proto3" json:"stdin,omitempty"`
+	// where the command came from, sender is kept for older services and
+	// is "channel_id:user_id"
+	Input string `protobuf:"bytes,5,opt,name=input,proto3" json:"input,omitempty"`
+	// the discord guild or slack workspace
+	GuildId     string `protobuf:"bytes,6,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`
+	ChannelId   string `protobuf:"bytes,7,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	UserId      string `protobuf:"bytes,8,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DisplayName string `protobuf:"bytes,9,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	MessageId   string `protobuf:"bytes,10,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ThreadId    string `protobuf:"bytes,11,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`
+	// when the command was sent in unix seconds
+	Timestamp     int64 `protobuf:"varint,12,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	DirectMessage bool  `protobuf:"varint,13,opt,name=direct_message,json=directMessage,proto3" json:"direct_message,omitempty"`
+	// set when the request is the user's answer to a follow up, args are
+	// then the command and subcommands that asked followed by the answer
+	// and text is only the answer
+	SessionId string `protobuf:"bytes,14,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// what the user typed before the command, like ! or a mention of the bot
+	Prefix               string   `protobuf:"bytes,15,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExecRequest) Reset()         { *m = ExecRequest{} }
+func (m *ExecRequest) String() string { return proto.CompactTextString(m) }
+func (*ExecRequest) ProtoMessage()    {}
+func (*ExecRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{9}
+}
+
+func (m *ExecRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecRequest.Unmarshal(m, b)
+}
+func (m *ExecRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecRequest.Marshal(b, m, deterministic)
+}
+func (m *ExecRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecRequest.Merge(m, src)
+}
+func (m *ExecRequest) XXX_Size() int {
+	return xxx_messageInfo_ExecRequest.Size(m)
+}
+func (m *ExecRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecRequest proto.InternalMessageInfo
+
+func (m *ExecRequest) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
+func (m *ExecRequest) GetArgs() []string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+func (m *ExecRequest) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
+func (m *ExecRequest) GetStdin() []byte {
+	if m != nil {
+		return m.Stdin
+	}
+	return nil
+}
+
+func (m *ExecRequest) GetInput() string {
+	if m != nil {
+		return m.Input
+	}
+	return ""
+}
+
+func (m *ExecRequest) GetGuildId() string {
+	if m != nil {
+		return m.GuildId
+	}
+	return ""
+}
+
+func (m *ExecRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *ExecRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *ExecRequest) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
+	}
+	return ""
+}
+
+func (m *ExecRequest) GetMessageId() string {
+	if m != nil {
+		return m.MessageId
+	}
+	return ""
+}
+
+func (m *ExecRequest) GetThreadId() string {
+	if m != nil {
+		return m.ThreadId
+	}
+	return ""
+}
+
+func (m *ExecRequest) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *ExecRequest) GetDirectMessage() bool {
+	if m != nil {
+		return m.DirectMessage
+	}
+	return false
+}
+
+func (m *ExecRequest) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func (m *ExecRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+type ExecResponse struct {
+	Result []byte `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	Error  string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// set when the command was accepted to run in the background, the
+	// service reports progress and the result through Jobs.Update
+	JobId string `protobuf:"bytes,3,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// rendered natively by inputs that support it, others fall back to
+	// result or a plain text rendering if result is empty
+	Rich *RichResponse `protobuf:"bytes,4,opt,name=rich,proto3" json:"rich,omitempty"`
+	// asks the user for more input, their next message in the channel is
+	// sent back to the service instead of being run as a command
+	FollowUp             *FollowUp `protobuf:"bytes,5,opt,name=follow_up,json=followUp,proto3" json:"follow_up,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ExecResponse) Reset()         { *m = ExecResponse{} }
+func (m *ExecResponse) String() string { return proto.CompactTextString(m) }
+func (*ExecResponse) ProtoMessage()    {}
+func (*ExecResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{10}
+}
+
+func (m *ExecResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecResponse.Unmarshal(m, b)
+}
+func (m *ExecResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecResponse.Marshal(b, m, deterministic)
+}
+func (m *ExecResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecResponse.Merge(m, src)
+}
+func (m *ExecResponse) XXX_Size() int {
+	return xxx_messageInfo_ExecResponse.Size(m)
+}
+func (m *ExecResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecResponse proto.InternalMessageInfo
+
+func (m *ExecResponse) GetResult() []byte {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *ExecResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *ExecResponse) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *ExecResponse) GetRich() *RichResponse {
+	if m != nil {
+		return m.Rich
+	}
+	return nil
+}
+
+func (m *ExecResponse) GetFollowUp() *FollowUp {
+	if m != nil {
+		return m.FollowUp
+	}
+	return nil
+}
+
+// FollowUp asks the user for more input. Inputs only pass on messages
+// addressed to the bot, so the answer needs the command prefix or to mention
+// the bot like a command does.
+type FollowUp struct {
+	// passed back with the answer so the service knows what it is for
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// how long to wait for the answer in seconds, the bot decides if it is 0
+	Timeout              int64    `protobuf:"varint,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FollowUp) Reset()         { *m = FollowUp{} }
+func (m *FollowUp) String() string { return proto.CompactTextString(m) }
+func (*FollowUp) ProtoMessage()    {}
+func (*FollowUp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{11}
+}
+
+func (m *FollowUp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FollowUp.Unmarshal(m, b)
+}
+func (m *FollowUp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FollowUp.Marshal(b, m, deterministic)
+}
+func (m *FollowUp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FollowUp.Merge(m, src)
+}
+func (m *FollowUp) XXX_Size() int {
+	return xxx_messageInfo_FollowUp.Size(m)
+}
+func (m *FollowUp) XXX_DiscardUnknown() {
+	xxx_messageInfo_FollowUp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FollowUp proto.InternalMessageInfo
+
+func (m *FollowUp) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func (m *FollowUp) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+// ExecStreamResponse is one update of a streamed command, the command has
+// finished when the service closes the stream
+type ExecStreamResponse struct {
+	// what the command is doing right now, replaces the previous status
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// output to add to what was sent so far
+	Result []byte `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	// ends the command with an error
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// the final response, shown instead of the output sent so far
+	Rich                 *RichResponse `protobuf:"bytes,4,opt,name=rich,proto3" json:"rich,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ExecStreamResponse) Reset()         { *m = ExecStreamResponse{} }
+func (m *ExecStreamResponse) String() string { return proto.CompactTextString(m) }
+func (*ExecStreamResponse) ProtoMessage()    {}
+func (*ExecStreamResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{12}
+}
+
+func (m *ExecStreamResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecStreamResponse.Unmarshal(m, b)
+}
+func (m *ExecStreamResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecStreamResponse.Marshal(b, m, deterministic)
+}
+func (m *ExecStreamResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecStreamResponse.Merge(m, src)
+}
+func (m *ExecStreamResponse) XXX_Size() int {
+	return xxx_messageInfo_ExecStreamResponse.Size(m)
+}
+func (m *ExecStreamResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecStreamResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecStreamResponse proto.InternalMessageInfo
+
+func (m *ExecStreamResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ExecStreamResponse) GetResult() []byte {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *ExecStreamResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *ExecStreamResponse) GetRich() *RichResponse {
+	if m != nil {
+		return m.Rich
+	}
+	return nil
+}
+
+// ChatEvent is something that happened in chat, which fields are set
+// depends on the type
+type ChatEvent struct {
+	// member_join, member_leave, reaction_add, reaction_remove,
+	// message_edit, message_delete or presence
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// the input the event happened on e.g. discord
+	Input string `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	// the discord guild or slack workspace
+	GuildId   string `protobuf:"bytes,3,opt,name=guild_id,json=guildId,proto3" json:"guild_id,omitempty"`
+	ChannelId string `protobuf:"bytes,4,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	UserId    string `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MessageId string `protobuf:"bytes,6,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// the emoji of reaction events
+	Emoji string `protobuf:"bytes,7,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	// the new text of edited messages
+	Text string `protobuf:"bytes,8,opt,name=text,proto3" json:"text,omitempty"`
+	// the new status of presence events e.g. online
+	Status string `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	// when the event happened in unix seconds
+	Timestamp            int64    `protobuf:"varint,10,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChatEvent) Reset()         { *m = ChatEvent{} }
+func (m *ChatEvent) String() string { return proto.CompactTextString(m) }
+func (*ChatEvent) ProtoMessage()    {}
+func (*ChatEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{13}
+}
+
+func (m *ChatEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChatEvent.Unmarshal(m, b)
+}
+func (m *ChatEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChatEvent.Marshal(b, m, deterministic)
+}
+func (m *ChatEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChatEvent.Merge(m, src)
+}
+func (m *ChatEvent) XXX_Size() int {
+	return xxx_messageInfo_ChatEvent.Size(m)
+}
+func (m *ChatEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChatEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChatEvent proto.InternalMessageInfo
+
+func (m *ChatEvent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ChatEvent) GetInput() string {
+	if m != nil {
+		return m.Input
+	}
+	return ""
+}
+
+func (m *ChatEvent) GetGuildId() string {
+	if m != nil {
+		return m.GuildId
+	}
+	return ""
+}
+
+func (m *ChatEvent) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *ChatEvent) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *ChatEvent) GetMessageId() string {
+	if m != nil {
+		return m.MessageId
+	}
+	return ""
+}
+
+func (m *ChatEvent) GetEmoji() string {
+	if m != nil {
+		return m.Emoji
+	}
+	return ""
+}
+
+func (m *ChatEvent) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
+func (m *ChatEvent) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ChatEvent) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+type ChatEventResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChatEventResponse) Reset()         { *m = ChatEventResponse{} }
+func (m *ChatEventResponse) String() string { return proto.CompactTextString(m) }
+func (*ChatEventResponse) ProtoMessage()    {}
+func (*ChatEventResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{14}
+}
+
+func (m *ChatEventResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChatEventResponse.Unmarshal(m, b)
+}
+func (m *ChatEventResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChatEventResponse.Marshal(b, m, deterministic)
+}
+func (m *ChatEventResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChatEventResponse.Merge(m, src)
+}
+func (m *ChatEventResponse) XXX_Size() int {
+	return xxx_messageInfo_ChatEventResponse.Size(m)
+}
+func (m *ChatEventResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChatEventResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChatEventResponse proto.InternalMessageInfo
+
+type RichResponse struct {
+	Title string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	// paragraphs of markdown
+	Text   []string `protobuf:"bytes,2,rep,name=text,proto3" json:"text,omitempty"`
+	Fields []*Field `protobuf:"bytes,3,rep,name=fields,proto3" json:"fields,omitempty"`
+	Tables []*Table `protobuf:"bytes,4,rep,name=tables,proto3" json:"tables,omitempty"`
+	// 0xRRGGBB
+	Colour      int32         `protobuf:"varint,5,opt,name=colour,proto3" json:"colour,omitempty"`
+	Footer      string        `protobuf:"bytes,6,opt,name=footer,proto3" json:"footer,omitempty"`
+	ImageUrl    string        `protobuf:"bytes,7,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	Attachments []*Attachment `protobuf:"bytes,8,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	// buttons and select menus, inputs that can't show them ask the user
+	// to reply with a number instead
+	Components           []*Component `protobuf:"bytes,9,rep,name=components,proto3" json:"components,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *RichResponse) Reset()         { *m = RichResponse{} }
+func (m *RichResponse) String() string { return proto.CompactTextString(m) }
+func (*RichResponse) ProtoMessage()    {}
+func (*RichResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{15}
+}
+
+func (m *RichResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RichResponse.Unmarshal(m, b)
+}
+func (m *RichResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RichResponse.Marshal(b, m, deterministic)
+}
+func (m *RichResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RichResponse.Merge(m, src)
+}
+func (m *RichResponse) XXX_Size() int {
+	return xxx_messageInfo_RichResponse.Size(m)
+}
+func (m *RichResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RichResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RichResponse proto.InternalMessageInfo
+
+func (m *RichResponse) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *RichResponse) GetText() []string {
+	if m != nil {
+		return m.Text
+	}
+	return nil
+}
+
+func (m *RichResponse) GetFields() []*Field {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+func (m *RichResponse) GetTables() []*Table {
+	if m != nil {
+		return m.Tables
+	}
+	return nil
+}
+
+func (m *RichResponse) GetColour() int32 {
+	if m != nil {
+		return m.Colour
+	}
+	return 0
+}
+
+func (m *RichResponse) GetFooter() string {
+	if m != nil {
+		return m.Footer
+	}
+	return ""
+}
+
+func (m *RichResponse) GetImageUrl() string {
+	if m != nil {
+		return m.ImageUrl
+	}
+	return ""
+}
+
+func (m *RichResponse) GetAttachments() []*Attachment {
+	if m != nil {
+		return m.Attachments
+	}
+	return nil
+}
+
+func (m *RichResponse) GetComponents() []*Component {
+	if m != nil {
+		return m.Components
+	}
+	return nil
+}
+
+type Component struct {
+	// passed back in Interact
+	Id   string        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type ComponentType `protobuf:"varint,2,opt,name=type,proto3,enum=go.micro.bot.ComponentType" json:"type,omitempty"`
+	// the text of a button
+	Label string      `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`
+	Style ButtonStyle `protobuf:"varint,4,opt,name=style,proto3,enum=go.micro.bot.ButtonStyle" json:"style,omitempty"`
+	// the options of a select menu
+	Options []*SelectOption `protobuf:"bytes,5,rep,name=options,proto3" json:"options,omitempty"`
+	// shown in a select menu before anything is picked
+	Placeholder string `protobuf:"bytes,6,opt,name=placeholder,proto3" json:"placeholder,omitempty"`
+	// how long the component can be used for in seconds, the bot decides
+	// if it is 0
+	ExpiresIn            int64    `protobuf:"varint,7,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Component) Reset()         { *m = Component{} }
+func (m *Component) String() string { return proto.CompactTextString(m) }
+func (*Component) ProtoMessage()    {}
+func (*Component) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{16}
 }
 
-func (m *HelpRequest) Reset()                    { *m = HelpRequest{} }
-func (m *HelpRequest) String() string            { return proto.CompactTextString(m) }
-func (*HelpRequest) ProtoMessage()               {}
-func (*HelpRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+func (m *Component) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Component.Unmarshal(m, b)
+}
+func (m *Component) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Component.Marshal(b, m, deterministic)
+}
+func (m *Component) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Component.Merge(m, src)
+}
+func (m *Component) XXX_Size() int {
+	return xxx_messageInfo_Component.Size(m)
+}
+func (m *Component) XXX_DiscardUnknown() {
+	xxx_messageInfo_Component.DiscardUnknown(m)
+}
 
-type HelpResponse struct {
-	Usage       string `protobuf:"bytes,1,opt,name=usage" json:"usage,omitempty"`
-	Description string `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
+var xxx_messageInfo_Component proto.InternalMessageInfo
+
+func (m *Component) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
 }
 
-func (m *HelpResponse) Reset()                    { *m = HelpResponse{} }
-func (m *HelpResponse) String() string            { return proto.CompactTextString(m) }
-func (*HelpResponse) ProtoMessage()               {}
-func (*HelpResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+func (m *Component) GetType() ComponentType {
+	if m != nil {
+		return m.Type
+	}
+	return ComponentType_BUTTON
+}
 
-func (m *HelpResponse) GetUsage() string {
+func (m *Component) GetLabel() string {
 	if m != nil {
-		return m.Usage
+		return m.Label
 	}
 	return ""
 }
 
-func (m *HelpResponse) GetDescription() string {
+func (m *Component) GetStyle() ButtonStyle {
+	if m != nil {
+		return m.Style
+	}
+	return ButtonStyle_PRIMARY
+}
+
+func (m *Component) GetOptions() []*SelectOption {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *Component) GetPlaceholder() string {
+	if m != nil {
+		return m.Placeholder
+	}
+	return ""
+}
+
+func (m *Component) GetExpiresIn() int64 {
+	if m != nil {
+		return m.ExpiresIn
+	}
+	return 0
+}
+
+type SelectOption struct {
+	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Label                string   `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Description          string   `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SelectOption) Reset()         { *m = SelectOption{} }
+func (m *SelectOption) String() string { return proto.CompactTextString(m) }
+func (*SelectOption) ProtoMessage()    {}
+func (*SelectOption) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{17}
+}
+
+func (m *SelectOption) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SelectOption.Unmarshal(m, b)
+}
+func (m *SelectOption) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SelectOption.Marshal(b, m, deterministic)
+}
+func (m *SelectOption) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SelectOption.Merge(m, src)
+}
+func (m *SelectOption) XXX_Size() int {
+	return xxx_messageInfo_SelectOption.Size(m)
+}
+func (m *SelectOption) XXX_DiscardUnknown() {
+	xxx_messageInfo_SelectOption.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SelectOption proto.InternalMessageInfo
+
+func (m *SelectOption) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *SelectOption) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *SelectOption) GetDescription() string {
 	if m != nil {
 		return m.Description
 	}
 	return ""
 }
 
-type ExecRequest struct {
-	Sender string   `protobuf:"bytes,1,opt,name=sender" json:"sender,omitempty"`
-	Args   []string `protobuf:"bytes,2,rep,name=args" json:"args,omitempty"`
+type InteractRequest struct {
+	ComponentId string `protobuf:"bytes,1,opt,name=component_id,json=componentId,proto3" json:"component_id,omitempty"`
+	// the values picked in a select menu
+	Values []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	// who used the component and where, args are empty
+	Invocation           *ExecRequest `protobuf:"bytes,3,opt,name=invocation,proto3" json:"invocation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
-func (m *ExecRequest) Reset()                    { *m = ExecRequest{} }
-func (m *ExecRequest) String() string            { return proto.CompactTextString(m) }
-func (*ExecRequest) ProtoMessage()               {}
-func (*ExecRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (m *InteractRequest) Reset()         { *m = InteractRequest{} }
+func (m *InteractRequest) String() string { return proto.CompactTextString(m) }
+func (*InteractRequest) ProtoMessage()    {}
+func (*InteractRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{18}
+}
 
-func (m *ExecRequest) GetSender() string {
+func (m *InteractRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InteractRequest.Unmarshal(m, b)
+}
+func (m *InteractRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InteractRequest.Marshal(b, m, deterministic)
+}
+func (m *InteractRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InteractRequest.Merge(m, src)
+}
+func (m *InteractRequest) XXX_Size() int {
+	return xxx_messageInfo_InteractRequest.Size(m)
+}
+func (m *InteractRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InteractRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InteractRequest proto.InternalMessageInfo
+
+func (m *InteractRequest) GetComponentId() string {
 	if m != nil {
-		return m.Sender
+		return m.ComponentId
 	}
 	return ""
 }
 
-func (m *ExecRequest) GetArgs() []string {
+func (m *InteractRequest) GetValues() []string {
 	if m != nil {
-		return m.Args
+		return m.Values
 	}
 	return nil
 }
 
-type ExecResponse struct {
-	Result []byte `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
-	Error  string `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+func (m *InteractRequest) GetInvocation() *ExecRequest {
+	if m != nil {
+		return m.Invocation
+	}
+	return nil
 }
 
-func (m *ExecResponse) Reset()                    { *m = ExecResponse{} }
-func (m *ExecResponse) String() string            { return proto.CompactTextString(m) }
-func (*ExecResponse) ProtoMessage()               {}
-func (*ExecResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+type Field struct {
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// whether the field can be shown next to others
+	Inline               bool     `protobuf:"varint,3,opt,name=inline,proto3" json:"inline,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
 
-func (m *ExecResponse) GetResult() []byte {
+func (m *Field) Reset()         { *m = Field{} }
+func (m *Field) String() string { return proto.CompactTextString(m) }
+func (*Field) ProtoMessage()    {}
+func (*Field) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{19}
+}
+
+func (m *Field) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Field.Unmarshal(m, b)
+}
+func (m *Field) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Field.Marshal(b, m, deterministic)
+}
+func (m *Field) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Field.Merge(m, src)
+}
+func (m *Field) XXX_Size() int {
+	return xxx_messageInfo_Field.Size(m)
+}
+func (m *Field) XXX_DiscardUnknown() {
+	xxx_messageInfo_Field.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Field proto.InternalMessageInfo
+
+func (m *Field) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Field) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *Field) GetInline() bool {
+	if m != nil {
+		return m.Inline
+	}
+	return false
+}
+
+type Table struct {
+	Header               []string `protobuf:"bytes,1,rep,name=header,proto3" json:"header,omitempty"`
+	Rows                 []*Row   `protobuf:"bytes,2,rep,name=rows,proto3" json:"rows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Table) Reset()         { *m = Table{} }
+func (m *Table) String() string { return proto.CompactTextString(m) }
+func (*Table) ProtoMessage()    {}
+func (*Table) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{20}
+}
+
+func (m *Table) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Table.Unmarshal(m, b)
+}
+func (m *Table) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Table.Marshal(b, m, deterministic)
+}
+func (m *Table) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Table.Merge(m, src)
+}
+func (m *Table) XXX_Size() int {
+	return xxx_messageInfo_Table.Size(m)
+}
+func (m *Table) XXX_DiscardUnknown() {
+	xxx_messageInfo_Table.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Table proto.InternalMessageInfo
+
+func (m *Table) GetHeader() []string {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *Table) GetRows() []*Row {
+	if m != nil {
+		return m.Rows
+	}
+	return nil
+}
+
+type Row struct {
+	Cells                []string `protobuf:"bytes,1,rep,name=cells,proto3" json:"cells,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Row) Reset()         { *m = Row{} }
+func (m *Row) String() string { return proto.CompactTextString(m) }
+func (*Row) ProtoMessage()    {}
+func (*Row) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{21}
+}
+
+func (m *Row) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Row.Unmarshal(m, b)
+}
+func (m *Row) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Row.Marshal(b, m, deterministic)
+}
+func (m *Row) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Row.Merge(m, src)
+}
+func (m *Row) XXX_Size() int {
+	return xxx_messageInfo_Row.Size(m)
+}
+func (m *Row) XXX_DiscardUnknown() {
+	xxx_messageInfo_Row.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Row proto.InternalMessageInfo
+
+func (m *Row) GetCells() []string {
+	if m != nil {
+		return m.Cells
+	}
+	return nil
+}
+
+type Attachment struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ContentType          string   `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Data                 []byte   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Attachment) Reset()         { *m = Attachment{} }
+func (m *Attachment) String() string { return proto.CompactTextString(m) }
+func (*Attachment) ProtoMessage()    {}
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{22}
+}
+
+func (m *Attachment) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Attachment.Unmarshal(m, b)
+}
+func (m *Attachment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Attachment.Marshal(b, m, deterministic)
+}
+func (m *Attachment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Attachment.Merge(m, src)
+}
+func (m *Attachment) XXX_Size() int {
+	return xxx_messageInfo_Attachment.Size(m)
+}
+func (m *Attachment) XXX_DiscardUnknown() {
+	xxx_messageInfo_Attachment.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Attachment proto.InternalMessageInfo
+
+func (m *Attachment) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Attachment) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+func (m *Attachment) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type JobUpdate struct {
+	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// what the job is doing right now
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// set once the job has finished, result or error are then posted
+	Done                 bool          `protobuf:"varint,3,opt,name=done,proto3" json:"done,omitempty"`
+	Result               []byte        `protobuf:"bytes,4,opt,name=result,proto3" json:"result,omitempty"`
+	Error                string        `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	Rich                 *RichResponse `protobuf:"bytes,6,opt,name=rich,proto3" json:"rich,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *JobUpdate) Reset()         { *m = JobUpdate{} }
+func (m *JobUpdate) String() string { return proto.CompactTextString(m) }
+func (*JobUpdate) ProtoMessage()    {}
+func (*JobUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{23}
+}
+
+func (m *JobUpdate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobUpdate.Unmarshal(m, b)
+}
+func (m *JobUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobUpdate.Marshal(b, m, deterministic)
+}
+func (m *JobUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobUpdate.Merge(m, src)
+}
+func (m *JobUpdate) XXX_Size() int {
+	return xxx_messageInfo_JobUpdate.Size(m)
+}
+func (m *JobUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobUpdate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobUpdate proto.InternalMessageInfo
+
+func (m *JobUpdate) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *JobUpdate) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *JobUpdate) GetDone() bool {
+	if m != nil {
+		return m.Done
+	}
+	return false
+}
+
+func (m *JobUpdate) GetResult() []byte {
 	if m != nil {
 		return m.Result
 	}
 	return nil
 }
 
-func (m *ExecResponse) GetError() string {
+func (m *JobUpdate) GetError() string {
 	if m != nil {
 		return m.Error
 	}
 	return ""
 }
 
+func (m *JobUpdate) GetRich() *RichResponse {
+	if m != nil {
+		return m.Rich
+	}
+	return nil
+}
+
+type JobUpdateResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobUpdateResponse) Reset()         { *m = JobUpdateResponse{} }
+func (m *JobUpdateResponse) String() string { return proto.CompactTextString(m) }
+func (*JobUpdateResponse) ProtoMessage()    {}
+func (*JobUpdateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{24}
+}
+
+func (m *JobUpdateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobUpdateResponse.Unmarshal(m, b)
+}
+func (m *JobUpdateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobUpdateResponse.Marshal(b, m, deterministic)
+}
+func (m *JobUpdateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobUpdateResponse.Merge(m, src)
+}
+func (m *JobUpdateResponse) XXX_Size() int {
+	return xxx_messageInfo_JobUpdateResponse.Size(m)
+}
+func (m *JobUpdateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobUpdateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobUpdateResponse proto.InternalMessageInfo
+
+// Notification is a message for one of channel_id, user_id or channel on
+// input
+type Notification struct {
+	// the input to post on e.g. discord
+	Input     string `protobuf:"bytes,1,opt,name=input,proto3" json:"input,omitempty"`
+	ChannelId string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// the message is sent to the user directly
+	UserId string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// a channel alias from the bot configuration
+	Channel string `protobuf:"bytes,4,opt,name=channel,proto3" json:"channel,omitempty"`
+	Message []byte `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	// rendered natively by inputs that support it, others fall back to
+	// message or a plain text rendering if message is empty
+	Rich                 *RichResponse `protobuf:"bytes,6,opt,name=rich,proto3" json:"rich,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Notification) Reset()         { *m = Notification{} }
+func (m *Notification) String() string { return proto.CompactTextString(m) }
+func (*Notification) ProtoMessage()    {}
+func (*Notification) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{25}
+}
+
+func (m *Notification) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Notification.Unmarshal(m, b)
+}
+func (m *Notification) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Notification.Marshal(b, m, deterministic)
+}
+func (m *Notification) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Notification.Merge(m, src)
+}
+func (m *Notification) XXX_Size() int {
+	return xxx_messageInfo_Notification.Size(m)
+}
+func (m *Notification) XXX_DiscardUnknown() {
+	xxx_messageInfo_Notification.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Notification proto.InternalMessageInfo
+
+func (m *Notification) GetInput() string {
+	if m != nil {
+		return m.Input
+	}
+	return ""
+}
+
+func (m *Notification) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *Notification) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *Notification) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *Notification) GetMessage() []byte {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (m *Notification) GetRich() *RichResponse {
+	if m != nil {
+		return m.Rich
+	}
+	return nil
+}
+
+type NotificationResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NotificationResponse) Reset()         { *m = NotificationResponse{} }
+func (m *NotificationResponse) String() string { return proto.CompactTextString(m) }
+func (*NotificationResponse) ProtoMessage()    {}
+func (*NotificationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51d7d70385167023, []int{26}
+}
+
+func (m *NotificationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NotificationResponse.Unmarshal(m, b)
+}
+func (m *NotificationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NotificationResponse.Marshal(b, m, deterministic)
+}
+func (m *NotificationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NotificationResponse.Merge(m, src)
+}
+func (m *NotificationResponse) XXX_Size() int {
+	return xxx_messageInfo_NotificationResponse.Size(m)
+}
+func (m *NotificationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NotificationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NotificationResponse proto.InternalMessageInfo
+
 func init() {
+	proto.RegisterEnum("go.micro.bot.ArgumentType", ArgumentType_name, ArgumentType_value)
+	proto.RegisterEnum("go.micro.bot.ComponentType", ComponentType_name, ComponentType_value)
+	proto.RegisterEnum("go.micro.bot.ButtonStyle", ButtonStyle_name, ButtonStyle_value)
 	proto.RegisterType((*HelpRequest)(nil), "go.micro.bot.HelpRequest")
 	proto.RegisterType((*HelpResponse)(nil), "go.micro.bot.HelpResponse")
+	proto.RegisterType((*CommandSchema)(nil), "go.micro.bot.CommandSchema")
+	proto.RegisterType((*CommandSpec)(nil), "go.micro.bot.CommandSpec")
+	proto.RegisterType((*Argument)(nil), "go.micro.bot.Argument")
+	proto.RegisterType((*Flag)(nil), "go.micro.bot.Flag")
+	proto.RegisterType((*CompleteRequest)(nil), "go.micro.bot.CompleteRequest")
+	proto.RegisterType((*CompleteResponse)(nil), "go.micro.bot.CompleteResponse")
+	proto.RegisterType((*Suggestion)(nil), "go.micro.bot.Suggestion")
 	proto.RegisterType((*ExecRequest)(nil), "go.micro.bot.ExecRequest")
 	proto.RegisterType((*ExecResponse)(nil), "go.micro.bot.ExecResponse")
+	proto.RegisterType((*FollowUp)(nil), "go.micro.bot.FollowUp")
+	proto.RegisterType((*ExecStreamResponse)(nil), "go.micro.bot.ExecStreamResponse")
+	proto.RegisterType((*ChatEvent)(nil), "go.micro.bot.ChatEvent")
+	proto.RegisterType((*ChatEventResponse)(nil), "go.micro.bot.ChatEventResponse")
+	proto.RegisterType((*RichResponse)(nil), "go.micro.bot.RichResponse")
+	proto.RegisterType((*Component)(nil), "go.micro.bot.Component")
+	proto.RegisterType((*SelectOption)(nil), "go.micro.bot.SelectOption")
+	proto.RegisterType((*InteractRequest)(nil), "go.micro.bot.InteractRequest")
+	proto.RegisterType((*Field)(nil), "go.micro.bot.Field")
+	proto.RegisterType((*Table)(nil), "go.micro.bot.Table")
+	proto.RegisterType((*Row)(nil), "go.micro.bot.Row")
+	proto.RegisterType((*Attachment)(nil), "go.micro.bot.Attachment")
+	proto.RegisterType((*JobUpdate)(nil), "go.micro.bot.JobUpdate")
+	proto.RegisterType((*JobUpdateResponse)(nil), "go.micro.bot.JobUpdateResponse")
+	proto.RegisterType((*Notification)(nil), "go.micro.bot.Notification")
+	proto.RegisterType((*NotificationResponse)(nil), "go.micro.bot.NotificationResponse")
 }
 
-func init() { proto.RegisterFile("bot.proto", fileDescriptor0) }
-
-var fileDescriptor0 = []byte{
-	// 237 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x90, 0xbf, 0x4a, 0xc4, 0x40,
-	0x10, 0xc6, 0x2f, 0xe7, 0x19, 0xc9, 0x24, 0x36, 0x8b, 0x1c, 0xf1, 0xaa, 0xb0, 0xd5, 0x55, 0x5b,
-	0x68, 0x25, 0x08, 0x16, 0xa2, 0x58, 0xe7, 0x0d, 0xf2, 0x67, 0x08, 0x81, 0x4b, 0x26, 0xce, 0x6c,
-	0xc0, 0x77, 0xf0, 0xa5, 0x25, 0xbb, 0x5b, 0x2c, 0x87, 0x5d, 0x7e, 0x19, 0xe6, 0x37, 0xdf, 0xb7,
-	0x90, 0xb5, 0x64, 0xcd, 0xc2, 0x64, 0x49, 0x15, 0x03, 0x99, 0x69, 0xec, 0x98, 0x4c, 0x4b, 0x56,
-	0xdf, 0x43, 0xfe, 0x85, 0x97, 0xa5, 0xc6, 0xef, 0x15, 0xc5, 0xea, 0x4f, 0x28, 0x3c, 0xca, 0x42,
-	0xb3, 0xa0, 0x7a, 0x80, 0xdb, 0x55, 0x9a, 0x01, 0xcb, 0xa4, 0x4a, 0xce, 0x59, 0xed, 0x41, 0x55,
-	0x90, 0xf7, 0x28, 0x1d, 0x8f, 0x8b, 0x1d, 0x69, 0x2e, 0xf7, 0x6e, 0x16, 0xff, 0xd2, 0x2f, 0x90,
-	0x7f, 0xfc, 0x60, 0x17, 0xb4, 0xea, 0x08, 0xa9, 0xe0, 0xdc, 0x23, 0x07, 0x4f, 0x20, 0xa5, 0xe0,
-	0xd0, 0xf0, 0x20, 0xe5, 0xbe, 0xba, 0x39, 0x67, 0xb5, 0xfb, 0xd6, 0xaf, 0x50, 0xf8, 0xd5, 0x10,
-	0xe1, 0x08, 0x29, 0xa3, 0xac, 0x17, 0xeb, 0x76, 0x8b, 0x3a, 0xd0, 0x16, 0x0d, 0x99, 0x89, 0xc3,
-	0x79, 0x0f, 0x4f, 0xbf, 0x09, 0xdc, 0xbd, 0xd3, 0x34, 0x35, 0x73, 0xaf, 0xde, 0xe0, 0xb0, 0x95,
-	0x51, 0x8f, 0x26, 0xae, 0x6c, 0xa2, 0xbe, 0xa7, 0xd3, 0x7f, 0x23, 0x7f, 0x58, 0xef, 0x36, 0xc1,
-	0x16, 0xe5, 0x5a, 0x10, 0x35, 0xbb, 0x16, 0xc4, 0xc9, 0xf5, 0xae, 0x4d, 0xdd, 0x93, 0x3f, 0xff,
-	0x05, 0x00, 0x00, 0xff, 0xff, 0x78, 0x68, 0x67, 0x86, 0x7f, 0x01, 0x00, 0x00,
+func init() { proto.RegisterFile("bot.proto", fileDescriptor_51d7d70385167023) }
+
+var fileDescriptor_51d7d70385167023 = []byte{
+	// 1863 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x58, 0xdd, 0x6e, 0x1b, 0xc7,
+	0x15, 0xd6, 0x72, 0xf9, 0xb3, 0x3c, 0xa4, 0x2c, 0x7a, 0x9c, 0x2a, 0x0c, 0x5d, 0xc7, 0xca, 0x16,
+	0x41, 0x55, 0x17, 0x50, 0x0a, 0x39, 0x40, 0xff, 0x2e, 0x0a, 0x89, 0xa6, 0x6d, 0xa6, 0x36, 0xd5,
+	0x0e, 0x25, 0x17, 0x01, 0x82, 0x08, 0xc3, 0xdd, 0x11, 0x39, 0xc6, 0x72, 0x67, 0xb3, 0x3b, 0x2b,
+	0x4b, 0xbd, 0xed, 0x45, 0x00, 0xf7, 0xbe, 0x8f, 0xd0, 0xde, 0xf4, 0x01, 0x7a, 0xd9, 0x87, 0xe8,
+	0x43, 0xf4, 0x15, 0x7a, 0x57, 0xcc, 0xdf, 0x72, 0x49, 0x91, 0xb2, 0x9d, 0xbb, 0x39, 0x3f, 0x7b,
+	0xe6, 0xcc, 0x77, 0xbe, 0x39, 0x67, 0x48, 0x68, 0x4e, 0xb8, 0x38, 0x48, 0x52, 0x2e, 0x38, 0x6a,
+	0x4f, 0xf9, 0xc1, 0x9c, 0x05, 0x29, 0x3f, 0x98, 0x70, 0xe1, 0x7f, 0x03, 0xad, 0xe7, 0x34, 0x4a,
+	0x30, 0xfd, 0x2e, 0xa7, 0x99, 0x40, 0x3f, 0x83, 0x8e, 0xf2, 0x0a, 0x78, 0x74, 0x7e, 0x49, 0xd3,
+	0x8c, 0xf1, 0xb8, 0xeb, 0xec, 0x39, 0xfb, 0x35, 0xbc, 0x63, 0xf5, 0xaf, 0xb4, 0x1a, 0xf9, 0xd0,
+	0x0e, 0x48, 0x42, 0x26, 0x2c, 0x62, 0x82, 0xd1, 0xac, 0x5b, 0xd9, 0x73, 0xf7, 0x9b, 0x78, 0x49,
+	0xe7, 0xff, 0xad, 0x02, 0x6d, 0x1d, 0x3e, 0x4b, 0x78, 0x9c, 0x51, 0xf4, 0x11, 0xd4, 0xf2, 0x8c,
+	0x4c, 0xa9, 0x0a, 0xda, 0xc4, 0x5a, 0x40, 0x7b, 0xd0, 0x0a, 0x69, 0x16, 0xa4, 0x2c, 0x11, 0x72,
+	0xc3, 0x8a, 0xb2, 0x95, 0x55, 0x32, 0x2f, 0x7a, 0x95, 0xd0, 0x40, 0xd0, 0xf0, 0x3c, 0xcd, 0x63,
+	0xc1, 0xe6, 0xb4, 0xeb, 0xee, 0x39, 0xfb, 0x2e, 0xde, 0xb1, 0x7a, 0xac, 0xd5, 0xe8, 0x21, 0xb4,
+	0xe6, 0xe4, 0xaa, 0xf0, 0xaa, 0x2a, 0x2f, 0x98, 0x93, 0x2b, 0xeb, 0xf0, 0x18, 0xea, 0x59, 0x30,
+	0xa3, 0x73, 0xd2, 0xad, 0xed, 0x39, 0xfb, 0xad, 0xc3, 0xfb, 0x07, 0x65, 0x44, 0x0e, 0xfa, 0x7c,
+	0x3e, 0x27, 0x71, 0x38, 0x56, 0x2e, 0xd8, 0xb8, 0xae, 0x05, 0xa6, 0xfe, 0x7e, 0xc0, 0x34, 0xd6,
+	0x00, 0xf3, 0x5f, 0x07, 0xb6, 0x97, 0x36, 0x42, 0xbf, 0x85, 0x56, 0x96, 0x4f, 0x02, 0xad, 0xcb,
+	0xba, 0xce, 0x9e, 0xbb, 0xdf, 0x3a, 0xfc, 0x64, 0x7d, 0x6a, 0x09, 0x0d, 0x70, 0xd9, 0x1b, 0x7d,
+	0x09, 0x4d, 0x92, 0x4e, 0xf3, 0x39, 0x8d, 0x85, 0x2e, 0x44, 0xeb, 0x70, 0x77, 0xf9, 0xd3, 0x23,
+	0x63, 0xc6, 0x0b, 0x47, 0xb4, 0x0f, 0xb5, 0x8b, 0x88, 0x4c, 0xb3, 0xae, 0xab, 0xbe, 0x40, 0xcb,
+	0x5f, 0x3c, 0x8d, 0xc8, 0x14, 0x6b, 0x07, 0xd4, 0x03, 0x8f, 0x5e, 0x91, 0x79, 0x12, 0xd1, 0xac,
+	0x5b, 0x55, 0xc7, 0x29, 0x64, 0xf4, 0x29, 0x40, 0x42, 0xd3, 0x39, 0xcb, 0x14, 0x26, 0x35, 0x55,
+	0xbb, 0x92, 0xc6, 0xff, 0xbb, 0x03, 0xad, 0x52, 0xe2, 0x08, 0x41, 0x35, 0x26, 0x73, 0xcb, 0x00,
+	0xb5, 0x5e, 0xd0, 0xa2, 0x72, 0x0b, 0x2d, 0xdc, 0x9b, 0xb4, 0x58, 0x94, 0xb2, 0xfa, 0xfe, 0xa5,
+	0xdc, 0x85, 0xfa, 0x8c, 0x85, 0x21, 0xd5, 0xc9, 0x7a, 0xd8, 0x48, 0xfe, 0x5f, 0x2a, 0xe0, 0x59,
+	0x98, 0xd6, 0x66, 0xf9, 0x6e, 0x9a, 0x1e, 0x40, 0x55, 0x5c, 0x27, 0x9a, 0x9a, 0x77, 0x0e, 0x7b,
+	0xeb, 0x4b, 0x70, 0x7a, 0x9d, 0x50, 0xac, 0xfc, 0x24, 0xae, 0x29, 0xfd, 0x2e, 0x67, 0x29, 0x0d,
+	0xd5, 0x09, 0x3c, 0x5c, 0xc8, 0xe8, 0x27, 0xb0, 0x1d, 0xd2, 0x0b, 0x92, 0x47, 0xe2, 0xfc, 0x92,
+	0x44, 0x39, 0x35, 0xd0, 0xb6, 0x8d, 0xf2, 0x95, 0xd4, 0xa1, 0x2e, 0x34, 0x82, 0x19, 0x67, 0x01,
+	0xcd, 0xba, 0x75, 0x55, 0x17, 0x2b, 0xca, 0xd0, 0x97, 0x24, 0x65, 0x24, 0x64, 0x41, 0xb7, 0xa1,
+	0x43, 0x5b, 0x59, 0xda, 0x02, 0x2e, 0xab, 0x27, 0x68, 0xd7, 0xd3, 0x36, 0x2b, 0xfb, 0xff, 0x73,
+	0xa0, 0x2a, 0x4b, 0xbf, 0xa9, 0x4e, 0xd9, 0x8c, 0xa7, 0xc2, 0xd6, 0x49, 0x09, 0xef, 0x51, 0x27,
+	0x8b, 0x4b, 0xf5, 0x07, 0xe0, 0x52, 0x7b, 0x17, 0x2e, 0xf5, 0xdb, 0x71, 0x69, 0xdc, 0xc0, 0x65,
+	0xe3, 0xd9, 0xff, 0x0c, 0x3b, 0x7d, 0xb3, 0xb6, 0x0d, 0xf1, 0x31, 0x34, 0x52, 0xbd, 0x54, 0x40,
+	0xdc, 0xb8, 0x92, 0x83, 0x2b, 0x1a, 0x18, 0x5f, 0x6c, 0x3d, 0xe5, 0x1e, 0xf6, 0x96, 0x19, 0xa4,
+	0x0a, 0x59, 0xb2, 0x2f, 0x49, 0xe9, 0x05, 0xbb, 0x32, 0x38, 0x19, 0xc9, 0x1f, 0x41, 0x67, 0xb1,
+	0xb7, 0xe9, 0x96, 0xbf, 0x91, 0x3d, 0x61, 0x3a, 0xa5, 0x99, 0x04, 0xd1, 0xf6, 0x84, 0xee, 0x72,
+	0x02, 0xe3, 0xc2, 0x01, 0x97, 0x9d, 0xfd, 0x5f, 0x01, 0x2c, 0x4c, 0xb2, 0x70, 0x1a, 0x2c, 0xd3,
+	0x77, 0x95, 0x20, 0xb5, 0x11, 0x99, 0xd0, 0xc8, 0x96, 0x53, 0x09, 0xfe, 0x3f, 0x5c, 0x68, 0x95,
+	0x8e, 0x25, 0x33, 0xce, 0x68, 0x1c, 0xd2, 0xd4, 0x7c, 0x6c, 0x24, 0x49, 0x10, 0x92, 0x4e, 0x6d,
+	0xe3, 0x57, 0x6b, 0xa9, 0x13, 0xf4, 0x4a, 0x98, 0xb3, 0xa9, 0xb5, 0x22, 0x8d, 0x08, 0x59, 0xac,
+	0xaa, 0xdf, 0xc6, 0x5a, 0x90, 0x5a, 0x16, 0x27, 0xb9, 0x30, 0xb4, 0xd6, 0x02, 0xfa, 0x04, 0xbc,
+	0x69, 0xce, 0xa2, 0xf0, 0x9c, 0x85, 0xa6, 0xae, 0x0d, 0x25, 0x0f, 0x43, 0xf4, 0x00, 0x20, 0x98,
+	0x91, 0x38, 0xa6, 0x91, 0x34, 0x36, 0x94, 0xb1, 0x69, 0x34, 0xc3, 0x10, 0x7d, 0x0c, 0x8d, 0x3c,
+	0xa3, 0xa9, 0xb4, 0x79, 0x3a, 0x4d, 0x29, 0x0e, 0x43, 0xf4, 0x19, 0xb4, 0x43, 0x96, 0x25, 0x11,
+	0xb9, 0x3e, 0x57, 0x7c, 0x6e, 0x1a, 0x7a, 0x6a, 0xdd, 0x48, 0xd2, 0xfa, 0x01, 0xc0, 0x9c, 0x66,
+	0xb2, 0xe7, 0xc8, 0xcf, 0x41, 0x87, 0x36, 0x9a, 0x61, 0x88, 0xee, 0x43, 0x53, 0xcc, 0x52, 0x4a,
+	0x54, 0x56, 0x2d, 0x5d, 0x4f, 0xad, 0x18, 0x86, 0xe8, 0xc7, 0xd0, 0x94, 0x53, 0x25, 0x13, 0x64,
+	0x9e, 0x74, 0xdb, 0x6a, 0xd8, 0x2c, 0x14, 0xe8, 0x73, 0xb8, 0x13, 0xb2, 0x94, 0x06, 0xe2, 0xdc,
+	0x84, 0xeb, 0x6e, 0x2b, 0xce, 0x6d, 0x6b, 0xed, 0x4b, 0xad, 0x94, 0x09, 0x64, 0x54, 0xb5, 0x4b,
+	0xb9, 0xc5, 0x1d, 0x9d, 0x80, 0xd1, 0x0c, 0xc3, 0x12, 0x67, 0x76, 0x96, 0x38, 0xf3, 0x2f, 0x07,
+	0xda, 0xba, 0x52, 0x86, 0x30, 0xbb, 0x50, 0x4f, 0x69, 0x96, 0x47, 0x9a, 0xac, 0x6d, 0x6c, 0x24,
+	0x09, 0x36, 0x4d, 0x53, 0x9e, 0xda, 0x42, 0x2b, 0x01, 0xfd, 0x08, 0xea, 0xaf, 0xf9, 0x44, 0xee,
+	0xa8, 0xcb, 0x55, 0x7b, 0xcd, 0x27, 0xc3, 0x50, 0x5e, 0xd6, 0x94, 0x05, 0x33, 0xd3, 0x52, 0x57,
+	0x2e, 0x2b, 0x66, 0xc1, 0xcc, 0x6e, 0x87, 0x95, 0x1f, 0x7a, 0x0c, 0xcd, 0x0b, 0x1e, 0x45, 0xfc,
+	0xcd, 0x79, 0x9e, 0x98, 0x91, 0xba, 0x32, 0x7c, 0x9e, 0x2a, 0xf3, 0x59, 0x82, 0xbd, 0x0b, 0xb3,
+	0xf2, 0xfb, 0xe0, 0x59, 0xed, 0xca, 0xe9, 0x9d, 0xd5, 0xd3, 0x77, 0xa1, 0x21, 0x01, 0xe5, 0xb9,
+	0xbe, 0x4c, 0x2e, 0xb6, 0xa2, 0xff, 0xd6, 0x01, 0x24, 0xcf, 0x3f, 0x16, 0x29, 0x25, 0xf3, 0x32,
+	0x0a, 0x99, 0x20, 0x22, 0xcf, 0x0a, 0xc2, 0x2a, 0xa9, 0x84, 0x4e, 0x65, 0x3d, 0x3a, 0x6e, 0x19,
+	0x9d, 0x0f, 0x84, 0xc1, 0xff, 0xbe, 0x02, 0xcd, 0xfe, 0x8c, 0x88, 0xc1, 0xa5, 0x99, 0x1f, 0xaa,
+	0xe3, 0x99, 0xee, 0x29, 0xd7, 0x0b, 0xca, 0x57, 0x36, 0x51, 0xde, 0xbd, 0x8d, 0xf2, 0xd5, 0x5b,
+	0x28, 0x5f, 0x5b, 0xa2, 0xfc, 0x32, 0x9f, 0xeb, 0xab, 0x7c, 0x96, 0xe7, 0x9d, 0xf3, 0xd7, 0xcc,
+	0x5c, 0x22, 0x2d, 0x14, 0x57, 0xd7, 0x2b, 0x5d, 0xdd, 0x05, 0x92, 0xcd, 0x25, 0x24, 0x97, 0x48,
+	0x0f, 0x2b, 0xa4, 0xf7, 0xef, 0xc1, 0xdd, 0x02, 0x08, 0x0b, 0x92, 0xff, 0x9f, 0x0a, 0xb4, 0xcb,
+	0xa8, 0xc9, 0x2c, 0x04, 0x13, 0x51, 0xd1, 0x92, 0x94, 0x50, 0x64, 0x61, 0x9a, 0x8a, 0xca, 0xe2,
+	0xe7, 0x50, 0xbf, 0x60, 0x34, 0x0a, 0xed, 0x43, 0xe5, 0xde, 0x0a, 0xbb, 0xa4, 0x0d, 0x1b, 0x17,
+	0xe9, 0x2c, 0xc8, 0xc4, 0x3e, 0x54, 0x6e, 0x38, 0x9f, 0x4a, 0x1b, 0x36, 0x2e, 0xf2, 0x7c, 0x01,
+	0x8f, 0x78, 0x9e, 0x2a, 0x00, 0x6b, 0xd8, 0x48, 0x52, 0x7f, 0xc1, 0xb9, 0xa0, 0xa9, 0x01, 0xcf,
+	0x48, 0xb2, 0x13, 0xb0, 0xb9, 0x84, 0x35, 0x4f, 0x23, 0x83, 0x9e, 0xa7, 0x14, 0x67, 0x69, 0x24,
+	0xbb, 0x35, 0x11, 0x82, 0x04, 0x33, 0xfd, 0x0c, 0xf3, 0xd6, 0x75, 0xeb, 0xa3, 0xc2, 0x01, 0x97,
+	0x9d, 0xd1, 0x2f, 0x01, 0xe4, 0x14, 0xe2, 0xb1, 0xfa, 0xb4, 0xa9, 0x3e, 0xfd, 0xf8, 0xc6, 0x63,
+	0x46, 0xdb, 0x71, 0xc9, 0xd5, 0x7f, 0x2b, 0x59, 0x67, 0x45, 0x74, 0x07, 0x2a, 0xc5, 0x0d, 0xaa,
+	0xb0, 0x10, 0x7d, 0x61, 0x58, 0x58, 0x51, 0x73, 0xf7, 0xfe, 0x86, 0x80, 0xa5, 0xc1, 0x5b, 0x4c,
+	0x04, 0xb7, 0x34, 0x11, 0xd0, 0x17, 0xb2, 0x83, 0x5f, 0x47, 0x76, 0x7e, 0xaf, 0x8c, 0xc0, 0xe3,
+	0x5c, 0x08, 0x1e, 0x8f, 0xa5, 0x03, 0xd6, 0x7e, 0xe8, 0x4b, 0x68, 0xf0, 0x44, 0x0f, 0xad, 0xda,
+	0x9e, 0x7b, 0xf3, 0xfa, 0x8c, 0x69, 0x44, 0x03, 0x71, 0xa2, 0x5c, 0xb0, 0x75, 0x95, 0xef, 0x88,
+	0x24, 0x22, 0x01, 0x9d, 0xf1, 0x28, 0x2c, 0xa0, 0x2f, 0xab, 0x24, 0xb1, 0xe9, 0x55, 0xc2, 0x52,
+	0x9a, 0x9d, 0xb3, 0x58, 0x15, 0xc0, 0xc5, 0x4d, 0xa3, 0x19, 0xc6, 0xfe, 0x37, 0xd0, 0x2e, 0x47,
+	0xfe, 0x90, 0xa9, 0xf7, 0xee, 0x47, 0x8c, 0xff, 0xbd, 0x03, 0x3b, 0xc3, 0x58, 0xd0, 0x94, 0x04,
+	0xc2, 0xce, 0xc6, 0xcf, 0xa0, 0x5d, 0x14, 0x63, 0xd1, 0xbc, 0x5a, 0x85, 0x4e, 0x37, 0x6f, 0xb5,
+	0xaf, 0x1d, 0x94, 0x46, 0x42, 0xbf, 0x06, 0x60, 0xf1, 0x25, 0x0f, 0x48, 0xb1, 0xdf, 0xad, 0x8f,
+	0x8b, 0x92, 0xb3, 0x3f, 0x84, 0x9a, 0x22, 0xfd, 0xa6, 0x37, 0x9a, 0x3e, 0x74, 0xa5, 0x7c, 0xe8,
+	0x5d, 0xa8, 0xb3, 0x38, 0x62, 0xb1, 0x7e, 0x9b, 0x7a, 0xd8, 0x48, 0xfe, 0x53, 0xa8, 0xa9, 0x2b,
+	0xa1, 0x5e, 0xc5, 0x94, 0xe8, 0x29, 0xaf, 0xd2, 0xd4, 0x12, 0xfa, 0x1c, 0xaa, 0x29, 0x7f, 0x63,
+	0x7f, 0x55, 0xdc, 0x5d, 0x69, 0x83, 0xfc, 0x0d, 0x56, 0x66, 0xff, 0x3e, 0xb8, 0x98, 0xbf, 0x91,
+	0x9b, 0x07, 0x34, 0x8a, 0x32, 0x13, 0x44, 0x0b, 0xfe, 0x9f, 0x00, 0x16, 0xc4, 0x5f, 0x9b, 0xb4,
+	0xc2, 0x31, 0x16, 0x12, 0xc5, 0x82, 0xb0, 0x0a, 0xc7, 0x58, 0x18, 0x82, 0xca, 0xcf, 0x42, 0x22,
+	0x88, 0xca, 0xbf, 0x8d, 0xd5, 0xda, 0xff, 0xa7, 0x03, 0xcd, 0xaf, 0xf8, 0xe4, 0x2c, 0x09, 0x89,
+	0xa0, 0xa5, 0x79, 0xe6, 0x94, 0xe7, 0xd9, 0xa2, 0x89, 0x55, 0x96, 0x9a, 0x98, 0x0c, 0xc8, 0x0b,
+	0x40, 0xd4, 0xba, 0x34, 0x22, 0xaa, 0xeb, 0x47, 0x44, 0x6d, 0xdd, 0x88, 0xa8, 0xbf, 0xe7, 0x88,
+	0xb8, 0x07, 0x77, 0x8b, 0x6c, 0x8b, 0xc6, 0xf8, 0x6f, 0x07, 0xda, 0x23, 0x2e, 0xd8, 0x05, 0xd3,
+	0xd5, 0x5d, 0x8c, 0x09, 0xa7, 0x3c, 0x26, 0x96, 0x67, 0x41, 0xe5, 0x96, 0x59, 0xe0, 0x2e, 0xcd,
+	0x02, 0xf5, 0x12, 0x56, 0x5e, 0x66, 0x80, 0x58, 0x51, 0x5a, 0xec, 0xa3, 0xa4, 0xa6, 0x0e, 0x6b,
+	0xc5, 0x0f, 0x3e, 0xd7, 0x2e, 0x7c, 0x54, 0x3e, 0x81, 0xb5, 0x3e, 0xfa, 0x16, 0xda, 0xe5, 0xc7,
+	0x3d, 0x02, 0xa8, 0x8f, 0x4f, 0xf1, 0x70, 0xf4, 0xac, 0xb3, 0x85, 0x1a, 0xe0, 0x0e, 0x47, 0xa7,
+	0x1d, 0x47, 0x2a, 0x47, 0x67, 0x2f, 0x8f, 0x07, 0xb8, 0x53, 0x41, 0x1e, 0x54, 0x8f, 0x4f, 0x4e,
+	0x5e, 0x74, 0x5c, 0xd4, 0x06, 0xef, 0xc9, 0x19, 0x3e, 0x3a, 0x1d, 0x9e, 0x8c, 0x3a, 0x55, 0xa9,
+	0x3f, 0x1b, 0x0f, 0x70, 0xa7, 0x86, 0x5a, 0xd0, 0xe8, 0x3f, 0x3f, 0x1a, 0x8d, 0x06, 0x2f, 0x3a,
+	0xf5, 0x47, 0x3f, 0x55, 0x3f, 0xa2, 0x17, 0x4d, 0x4c, 0xc6, 0x3a, 0x3e, 0x3b, 0x3d, 0x3d, 0x19,
+	0x75, 0xb6, 0xd4, 0x66, 0x83, 0x17, 0x83, 0xfe, 0x69, 0xc7, 0x79, 0x74, 0x0c, 0xad, 0x52, 0x97,
+	0x92, 0x41, 0xfe, 0x80, 0x87, 0x2f, 0x8f, 0xf0, 0xd7, 0x9d, 0x2d, 0xb4, 0x0d, 0xcd, 0xf1, 0xa0,
+	0x7f, 0x32, 0x7a, 0x22, 0x45, 0x47, 0xda, 0xc6, 0x67, 0xfd, 0xfe, 0x60, 0x3c, 0xee, 0x54, 0x64,
+	0x8c, 0x27, 0x47, 0xa3, 0x67, 0x03, 0xdc, 0x71, 0x0f, 0xff, 0xea, 0x40, 0xc3, 0xfc, 0xa0, 0x44,
+	0xbf, 0x83, 0xaa, 0xfc, 0x5b, 0x03, 0xad, 0xdc, 0xd7, 0xd2, 0x3f, 0x29, 0xbd, 0xde, 0x3a, 0x93,
+	0x29, 0xf9, 0x96, 0x0c, 0x20, 0x2f, 0x37, 0xda, 0x7c, 0xe1, 0x7b, 0xbd, 0x75, 0x26, 0x1b, 0xe0,
+	0xf0, 0xdb, 0xc5, 0xff, 0x07, 0xea, 0xf1, 0x83, 0x5e, 0x02, 0x2c, 0x9e, 0x42, 0xb7, 0xc5, 0xdd,
+	0xbb, 0x69, 0x5a, 0x7e, 0x3f, 0xf9, 0x5b, 0xbf, 0x70, 0x0e, 0xbf, 0x06, 0x30, 0x3f, 0x47, 0x24,
+	0x25, 0x7f, 0x0f, 0x9e, 0x91, 0x28, 0x7a, 0x70, 0x73, 0x8a, 0x94, 0x7e, 0x30, 0xf5, 0x3e, 0xdd,
+	0x64, 0x2e, 0x52, 0xff, 0x23, 0x40, 0xf1, 0x3c, 0xc8, 0x50, 0x1f, 0x6a, 0x6a, 0x85, 0x56, 0xc7,
+	0x9d, 0x75, 0xe9, 0x3d, 0xdc, 0x60, 0x28, 0x85, 0x7c, 0x05, 0x2d, 0xdb, 0x99, 0x65, 0xba, 0xcf,
+	0xc0, 0xb3, 0xe2, 0x6a, 0xba, 0x2b, 0x0d, 0xfc, 0x1d, 0x28, 0xbf, 0x80, 0xea, 0x57, 0x7c, 0x92,
+	0xa1, 0x27, 0x50, 0x37, 0x3d, 0x66, 0x25, 0xcb, 0xe2, 0x3a, 0xf7, 0x1e, 0x6e, 0x30, 0x94, 0xa2,
+	0x61, 0xa8, 0xab, 0x6b, 0x72, 0x8d, 0x9e, 0x43, 0x75, 0x4c, 0xe3, 0x10, 0xad, 0xec, 0x5e, 0xbe,
+	0x44, 0x3d, 0x7f, 0xb3, 0x6d, 0x11, 0x73, 0x52, 0x57, 0xff, 0x3e, 0x3d, 0xfe, 0xff, 0x00, 0xd3,
+	0x82, 0xbf, 0x0d, 0xe1, 0x13, 0x00, 0x00,
 }
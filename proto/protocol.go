@@ -0,0 +1,38 @@
+package go_micro_bot
+
+// ProtocolVersion is the version of the Command protocol. The bot sends it in
+// HelpRequest and services send the version they were built with in
+// HelpResponse.
+const ProtocolVersion = 1
+
+// TokenHeader is the metadata header services put the token the bot was
+// configured with for them in when calling the Notify and Jobs services
+const TokenHeader = "Bot-Token"
+
+// Capabilities a service can advertise in HelpResponse. Services only
+// register the optional services they implement next to Command, the bot
+// only calls the ones a service advertises.
+const (
+	// CapabilityStream is for services that register CommandStream
+	CapabilityStream = "stream"
+	// CapabilityRich is for services that send rich responses
+	CapabilityRich = "rich"
+	// CapabilityComplete is for services that register Completion
+	CapabilityComplete = "complete"
+	// CapabilityInteract is for services that register Interaction
+	CapabilityInteract = "interact"
+	// CapabilityEvents is for services that register ChatEvents
+	CapabilityEvents = "events"
+	// CapabilityFollowUp is for services that ask follow up questions
+	CapabilityFollowUp = "follow_up"
+)
+
+// Capabilities are all the capabilities of ProtocolVersion
+var Capabilities = []string{
+	CapabilityStream,
+	CapabilityRich,
+	CapabilityComplete,
+	CapabilityInteract,
+	CapabilityEvents,
+	CapabilityFollowUp,
+}
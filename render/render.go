@@ -0,0 +1,138 @@
+// Package render turns rich responses into text for inputs that can't show
+// them natively
+package render
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	proto "github.com/chremoas/chremoas/proto"
+)
+
+// Text renders a rich response as markdown
+func Text(rich *proto.RichResponse) string {
+	var parts []string
+
+	if len(rich.Title) > 0 {
+		parts = append(parts, "**"+rich.Title+"**")
+	}
+
+	parts = append(parts, rich.Text...)
+
+	if len(rich.Fields) > 0 {
+		var fields []string
+		for _, f := range rich.Fields {
+			fields = append(fields, fmt.Sprintf("**%s**: %s", f.Name, f.Value))
+		}
+		parts = append(parts, strings.Join(fields, "\n"))
+	}
+
+	for _, t := range rich.Tables {
+		parts = append(parts, "```\n"+Table(t)+"```")
+	}
+
+	if len(rich.ImageUrl) > 0 {
+		parts = append(parts, rich.ImageUrl)
+	}
+
+	if len(rich.Footer) > 0 {
+		parts = append(parts, "_"+rich.Footer+"_")
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// Table renders a table with its columns aligned, it is meant to be shown in
+// a monospaced font.
+func Table(t *proto.Table) string {
+	rows := [][]string{t.Header}
+	for _, r := range t.Rows {
+		rows = append(rows, r.Cells)
+	}
+
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			if n := utf8.RuneCountInString(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	var sb strings.Builder
+	for r, row := range rows {
+		if r == 0 && len(row) == 0 {
+			continue
+		}
+
+		for i, cell := range row {
+			if i > 0 {
+				sb.WriteString("  ")
+			}
+			sb.WriteString(cell)
+			if i < len(row)-1 {
+				sb.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)))
+			}
+		}
+		sb.WriteString("\n")
+
+		// underline the header
+		if r == 0 {
+			for i, w := range widths {
+				if i > 0 {
+					sb.WriteString("  ")
+				}
+				sb.WriteString(strings.Repeat("-", w))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// Choice is something that can be picked from the components of a rich
+// response, inputs that can't show components number them instead
+type Choice struct {
+	ComponentID string
+	// Value is the option picked from a select menu, empty for buttons
+	Value string
+	Label string
+}
+
+// Choices lists what can be picked from the components of rich, in the order
+// Prompt numbers them
+func Choices(rich *proto.RichResponse) []Choice {
+	var choices []Choice
+	for _, c := range rich.Components {
+		if c.Type == proto.ComponentType_BUTTON {
+			choices = append(choices, Choice{ComponentID: c.Id, Label: c.Label})
+			continue
+		}
+
+		for _, o := range c.Options {
+			label := o.Label
+			if len(label) == 0 {
+				label = o.Value
+			}
+			if len(c.Placeholder) > 0 {
+				label = c.Placeholder + ": " + label
+			}
+			choices = append(choices, Choice{ComponentID: c.Id, Value: o.Value, Label: label})
+		}
+	}
+	return choices
+}
+
+// Prompt asks the user to reply with the number of one of choices
+func Prompt(choices []Choice) string {
+	lines := []string{"Reply with the number of your choice:"}
+	for i, c := range choices {
+		lines = append(lines, fmt.Sprintf("%d. %s", i+1, c.Label))
+	}
+	return strings.Join(lines, "\n")
+}